@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 
 	"time"
 
@@ -34,16 +35,19 @@ type AuthToken struct {
 // Config contains configuration needed to perform reddit API requests. This consists of
 // credentials used to obtain a token and the current token. The credentials must be provided
 // by the user of this library. Calling AuthScript then authenticates the client and populates
-// the AuthToken.
+// the AuthToken. Use Configure to change the servers, transport, clock, user agent or Limiter used
+// for requests.
 type Config struct {
 	Credentials Credentials `json:"credentials"`
 	AuthToken   AuthToken   `json:"token"`
+
+	settings settings
 }
 
 const (
-	// RedditAuthURL is the URL used to obtain an authentication token.
+	// RedditAuthURL is the URL used to obtain an authentication token, unless changed with WithAuthURL.
 	RedditAuthURL     = "https://www.reddit.com/api/v1/access_token"
-	// RedditAPIURL is the base URL used to make API calls.
+	// RedditAPIURL is the base URL used to make API calls, unless changed with WithAPIURL.
 	RedditAPIURL      = "https://oauth.reddit.com"
 	// DefaultConfigFile is the default file used to store API credentials.
 	DefaultConfigFile = "~/.reddit_creds"
@@ -110,10 +114,10 @@ func (c *Config) Save(file string) error {
 // If authentication is successful Config.AuthToken is populated with the received authentication token.
 // Use Config.Save to save this authentication token.
 func (c *Config) AuthScript(client *http.Client) error {
-	if c.AuthToken.Token != "" && time.Unix(c.AuthToken.Expires, 0).After(clock.Now()) {
+	if c.AuthToken.Token != "" && time.Unix(c.AuthToken.Expires, 0).After(c.clock().Now()) {
 		return nil
 	}
-	token, err := requestToken(c.Credentials, client)
+	token, err := c.requestToken(client)
 	if err != nil {
 		return err
 	}
@@ -133,51 +137,136 @@ func (passthroughDoer) do(req *http.Request, client *http.Client) (*http.Respons
 
 var defaultDoer doer = passthroughDoer{}
 
-func httpRequest(req *http.Request, client *http.Client) ([]byte, error) {
-	resp, err := defaultDoer.do(req, client)
-	if err != nil {
-		return nil, fmt.Errorf("http request to %v failed: %v", req.URL, err)
+// StatusError is returned when reddit responds to a request with a status other than 200 OK.
+type StatusError struct {
+	StatusCode int    // HTTP status code of the response
+	URL        string // URL of the request
+	Body       []byte // Body of the response
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("http error %d for %v: %v", e.StatusCode, e.URL, string(e.Body))
+}
+
+// httpRequest sends the request in base, retrying it if it is rate limited. base is copied into the
+// RequestInfo passed to middleware for each attempt. check is called with the final response and returns
+// the error for the request, if any. If check is nil any status other than 200 OK is an error.
+func (c *Config) httpRequest(client *http.Client, base RequestInfo, check func(info *RequestInfo) error) ([]byte, error) {
+	if check == nil {
+		check = statusOK
+	}
+	limiter, clock, req := c.limiter(), c.clock(), base.Request
+	for attempt := 0; ; attempt++ {
+		limiter.Wait()
+		info := base
+		info.Request, info.Attempt, info.Start = req, attempt, clock.Now()
+		if err := c.beforeRequest(&info); err != nil {
+			return nil, c.onError(&info, err)
+		}
+		req = info.Request
+		sent := clock.Now()
+		resp, err := c.doer().do(req, client)
+		if err != nil {
+			info.Duration = clock.Since(sent)
+			return nil, c.onError(&info, fmt.Errorf("http request to %v failed: %v", req.URL, err))
+		}
+		data, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		info.Duration, info.Response, info.Body = clock.Since(sent), resp, data
+		if err != nil {
+			return nil, c.onError(&info, fmt.Errorf("failed to read http response from %v: %v", req.URL, err))
+		}
+		limit, hasLimit := parseRateLimit(resp.Header, clock.Now())
+		if hasLimit {
+			limiter.Update(limit)
+		}
+		info.RateLimit, info.HasRateLimit = limit, hasLimit
+		retry := resp.StatusCode == http.StatusTooManyRequests && attempt < maxRetries && rewind(req)
+		if !retry {
+			info.Err = check(&info)
+		}
+		c.afterResponse(&info)
+		if retry {
+			// Retry once the current rate limit period ends.
+			if !hasLimit {
+				limit.Reset = clock.Now().Add(retryAfter(resp.Header))
+			}
+			limiter.Update(RateLimit{Used: limit.Used, Remaining: 0, Reset: limit.Reset})
+			continue
+		}
+		if info.Err != nil {
+			return nil, c.onError(&info, info.Err)
+		}
+		return data, nil
+	}
+}
+
+// statusOK returns a StatusError if the response in info does not have status 200 OK.
+func statusOK(info *RequestInfo) error {
+	if info.Response.StatusCode != http.StatusOK {
+		return &StatusError{StatusCode: info.Response.StatusCode, URL: info.Request.URL.String(), Body: info.Body}
+	}
+	return nil
+}
+
+// rewind resets the body of req so it can be sent again. It returns false if this is not possible.
+func rewind(req *http.Request) bool {
+	if req.Body == nil {
+		return true
+	}
+	if req.GetBody == nil {
+		return false
 	}
-	defer resp.Body.Close()
-	data, err := ioutil.ReadAll(resp.Body)
+	body, err := req.GetBody()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read http response from %v: %v", req.URL, err)
+		return false
 	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("http error %d for %v: %v", resp.StatusCode, req.URL, string(data))
+	req.Body = body
+	return true
+}
+
+// retryAfter returns the delay requested by the Retry-After header, defaulting to a second.
+func retryAfter(h http.Header) time.Duration {
+	if secs, err := strconv.Atoi(h.Get("Retry-After")); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
 	}
-	return data, nil
+	return time.Second
 }
 
-func requestToken(c Credentials, client *http.Client) (AuthToken, error) {
-	formData := fmt.Sprintf("grant_type=password&username=%s&password=%s", c.Username, c.Password)
+func (c *Config) requestToken(client *http.Client) (AuthToken, error) {
+	creds := c.Credentials
+	formData := fmt.Sprintf("grant_type=password&username=%s&password=%s", creds.Username, creds.Password)
 	body := bytes.NewBufferString(formData)
 
-	req, err := http.NewRequest(http.MethodPost, RedditAuthURL, body)
+	req, err := http.NewRequest(http.MethodPost, c.authURL(), body)
 	if err != nil {
 		return AuthToken{}, fmt.Errorf("failed to create auth request: %v", err)
 	}
 
-	req.Header.Add("User-Agent", c.UserAgent)
-	req.SetBasicAuth(c.ClientID, c.ClientSecret)
+	req.Header.Add("User-Agent", c.userAgent())
+	req.SetBasicAuth(creds.ClientID, creds.ClientSecret)
 
-	authTime := clock.Now()
-	data, err := httpRequest(req, client)
-	if err != nil {
-		return AuthToken{}, err
-	}
+	authTime := c.clock().Now()
 	d := struct {
 		Token     string `json:"access_token"`
 		ExpiresIn int64  `json:"expires_in"`
 		Type      string `json:"token_type"`
 	}{}
-	if err := json.Unmarshal(data, &d); err != nil {
-		return AuthToken{}, fmt.Errorf("invalid token response: %v: %s", err, string(data))
-	}
-
-	errors := notZero("token", d.Token != "") + notZero("expiration", d.ExpiresIn != 0) + notZero("token type", d.Type != "")
-	if errors != "" {
-		return AuthToken{}, fmt.Errorf("incomplete token response: %s", errors)
+	_, err = c.httpRequest(client, RequestInfo{Request: req, Token: true}, func(info *RequestInfo) error {
+		if err := statusOK(info); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(info.Body, &d); err != nil {
+			return fmt.Errorf("invalid token response: %v: %s", err, string(info.Body))
+		}
+		errors := notZero("token", d.Token != "") + notZero("expiration", d.ExpiresIn != 0) + notZero("token type", d.Type != "")
+		if errors != "" {
+			return fmt.Errorf("incomplete token response: %s", errors)
+		}
+		return nil
+	})
+	if err != nil {
+		return AuthToken{}, err
 	}
 	return AuthToken{
 		Type: d.Type, Token: d.Token, Expires: authTime.Unix() + d.ExpiresIn,
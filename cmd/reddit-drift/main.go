@@ -0,0 +1,93 @@
+// Command reddit-drift reports how captured reddit responses differ from the types in the reddit package.
+//
+// Every Thing in the JSON files under the provided paths is checked against the struct registered for its
+// kind. Fields present in the JSON but absent from the struct, fields whose JSON type cannot be decoded
+// into the struct field and kinds with no struct are listed along with the number of times they were seen.
+// The exit status is 1 if any differences are found, so it can be used to notice when types.go has fallen
+// behind reddit's API:
+//
+//	go run ./cmd/reddit-drift testdata
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	reddit "github.com/sridharv/reddit-go"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s <file or directory>...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	r := reddit.NewDriftReport()
+	for _, path := range flag.Args() {
+		if err := addPath(r, path); err != nil {
+			log.Fatal(err)
+		}
+	}
+	printReport(os.Stdout, r)
+	if r.HasDrift() {
+		os.Exit(1)
+	}
+}
+
+// addPath adds the JSON file at path, or every .json file under path if it is a directory, to r.
+func addPath(r *reddit.DriftReport, path string) error {
+	return filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || filepath.Ext(p) != ".json" {
+			return err
+		}
+		data, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if err := r.Add(data); err != nil {
+			return fmt.Errorf("%s: %v", p, err)
+		}
+		return nil
+	})
+}
+
+// printSection prints counts sorted by decreasing count, then by name.
+func printSection(w io.Writer, title string, counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	fmt.Fprintf(w, "%s:\n", title)
+	for _, name := range names {
+		fmt.Fprintf(w, "%8d  %s\n", counts[name], name)
+	}
+}
+
+func printReport(w io.Writer, r *reddit.DriftReport) {
+	printSection(w, "Things checked", r.Things)
+	printSection(w, "Missing fields", r.Missing)
+	printSection(w, "Type mismatches", r.Mismatched)
+	printSection(w, "Unknown kinds", r.UnknownKinds)
+	if !r.HasDrift() {
+		fmt.Fprintln(w, "No drift found.")
+	}
+}
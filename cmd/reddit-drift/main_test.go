@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	reddit "github.com/sridharv/reddit-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReport(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "reddit-drift")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"a.json":     `{"kind": "t3", "data": {"id": "a", "score": "1", "new_field": 1}}`,
+		"b/b.json":   `[{"kind": "t3", "data": {"id": "b", "new_field": 2, "other": true}}]`,
+		"ignore.txt": `not json`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(ioutil.WriteFile(path, []byte(content), 0644))
+	}
+
+	r := reddit.NewDriftReport()
+	require.NoError(addPath(r, dir))
+	var out bytes.Buffer
+	printReport(&out, r)
+	require.Equal(`Things checked:
+       2  t3
+Missing fields:
+       2  Link.new_field
+       1  Link.other
+Type mismatches:
+       1  Link.score: string into int
+`, out.String())
+
+	out.Reset()
+	printReport(&out, reddit.NewDriftReport())
+	require.Equal("No drift found.\n", out.String())
+}
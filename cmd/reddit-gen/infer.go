@@ -0,0 +1,302 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/serenize/snaker"
+)
+
+// defaultKinds maps the kinds decoded by Thing.UnmarshalJSON to their structs, in the order the structs
+// are generated.
+var defaultKinds = []struct{ kind, name string }{
+	{"t1", "Comment"},
+	{"t2", "Account"},
+	{"t3", "Link"},
+	{"t4", "Message"},
+	{"t5", "SubReddit"},
+	{"more", "More"},
+	{"modaction", "ModAction"},
+	{"wikipage", "WikiPage"},
+	{"wikipagesettings", "WikiPageSettings"},
+	{"subreddit_settings", "SubRedditSettings"},
+	{"LabeledMulti", "LabeledMulti"},
+}
+
+var fullnameRe = regexp.MustCompile(`^t[1-9]_[0-9a-z]+$`)
+
+// shape records the JSON values seen for a single field.
+type shape struct {
+	null, boolean, integer, float, str, object, array bool
+	notFullname, thing, notThing                      bool
+	elem                                              *shape // Shape of array elements, nil if only empty arrays were seen.
+}
+
+func isThing(m map[string]interface{}) bool {
+	_, hasKind := m["kind"].(string)
+	_, hasData := m["data"]
+	return hasKind && hasData
+}
+
+func (s *shape) observe(v interface{}) {
+	switch v := v.(type) {
+	case nil:
+		s.null = true
+	case bool:
+		s.boolean = true
+	case json.Number:
+		if strings.ContainsAny(string(v), ".eE") {
+			s.float = true
+		} else {
+			s.integer = true
+		}
+	case string:
+		s.str = true
+		if !fullnameRe.MatchString(v) {
+			s.notFullname = true
+		}
+	case map[string]interface{}:
+		s.object = true
+		if isThing(v) {
+			s.thing = true
+		} else {
+			s.notThing = true
+		}
+	case []interface{}:
+		s.array = true
+		for _, e := range v {
+			if s.elem == nil {
+				s.elem = &shape{}
+			}
+			s.elem.observe(e)
+		}
+	}
+}
+
+// isTimestamp reports whether numbers sent for key are times in seconds since the epoch.
+func isTimestamp(key string) bool {
+	return key == "created" || key == "timestamp" || strings.HasSuffix(key, "_utc")
+}
+
+// goType returns the Go type for a field named key with shape s. Nullable booleans and numbers are
+// pointers, and fields sent with more than one JSON type are left as json.RawMessage.
+func goType(key string, s *shape) string {
+	number := s.integer || s.float
+	n := 0
+	for _, seen := range []bool{s.boolean, number, s.str, s.object, s.array} {
+		if seen {
+			n++
+		}
+	}
+	if n != 1 {
+		return "json.RawMessage"
+	}
+	ptr := func(t string) string {
+		if s.null {
+			return "*" + t
+		}
+		return t
+	}
+	switch {
+	case s.boolean:
+		return ptr("bool")
+	case number && isTimestamp(key):
+		return "Timestamp"
+	case s.float:
+		return ptr("float64")
+	case s.integer:
+		return ptr("int")
+	case s.str && !s.notFullname:
+		return "Fullname"
+	case s.str:
+		return "string"
+	case s.object && !s.notThing:
+		return "*Thing"
+	case s.array && s.elem != nil:
+		elem := strings.TrimPrefix(goType("", s.elem), "*")
+		if elem != "json.RawMessage" && s.elem.null {
+			elem = "json.RawMessage"
+		}
+		return "[]" + elem
+	}
+	return "json.RawMessage"
+}
+
+// extraInitialisms are initialisms used by reddit that snaker does not know about.
+var extraInitialisms = map[string]string{"nsfw": "NSFW", "utc": "UTC"}
+
+var keyRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// goName returns the Go field name for a JSON key, or "" if the key cannot be used as a field name.
+func goName(key string) string {
+	if !keyRe.MatchString(key) {
+		return ""
+	}
+	var b strings.Builder
+	for _, w := range strings.Split(key, "_") {
+		if i, ok := extraInitialisms[strings.ToLower(w)]; ok {
+			b.WriteString(i)
+		} else {
+			b.WriteString(snaker.SnakeToCamel(w))
+		}
+	}
+	return b.String()
+}
+
+// tableTypes maps the types used in the reddit JSON wiki tables to Go types.
+var tableTypes = map[string]string{
+	"string":       "string",
+	"int":          "int",
+	"long":         "int64",
+	"boolean":      "bool",
+	"double":       "float64",
+	"float":        "float64",
+	"object":       "json.RawMessage",
+	"thing":        "*Thing",
+	"list<thing>":  "[]Thing",
+	"list<string>": "[]string",
+}
+
+// generator holds the fields observed for each struct.
+type generator struct {
+	kinds    map[string]string            // Kind to struct name.
+	shapes   map[string]map[string]*shape // Struct name to JSON key to shape.
+	declared map[string]map[string]string // Struct name to JSON key to the type from a field table.
+}
+
+func newGenerator(kinds map[string]string) *generator {
+	return &generator{kinds: kinds, shapes: map[string]map[string]*shape{}, declared: map[string]map[string]string{}}
+}
+
+// structNames returns the names of the structs to generate, in order.
+func (g *generator) structNames() []string {
+	var names, extra []string
+	seen := map[string]bool{}
+	for _, k := range defaultKinds {
+		if name, ok := g.kinds[k.kind]; ok && !seen[name] {
+			names, seen[name] = append(names, name), true
+		}
+	}
+	for _, name := range g.kinds {
+		if !seen[name] {
+			extra, seen[name] = append(extra, name), true
+		}
+	}
+	sort.Strings(extra)
+	return append(names, extra...)
+}
+
+// addSamples records the Things in the JSON file at path, or in all .json files under path if it is a
+// directory.
+func (g *generator) addSamples(path string) error {
+	return filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || filepath.Ext(p) != ".json" {
+			return err
+		}
+		data, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		d := json.NewDecoder(bytes.NewReader(data))
+		d.UseNumber()
+		var v interface{}
+		if err := d.Decode(&v); err != nil {
+			return fmt.Errorf("%s: %v", p, err)
+		}
+		g.walk(v)
+		return nil
+	})
+}
+
+func (g *generator) walk(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if data, ok := v["data"].(map[string]interface{}); ok && isThing(v) {
+			if name, ok := g.kinds[v["kind"].(string)]; ok {
+				g.record(name, data)
+			}
+		}
+		for _, e := range v {
+			g.walk(e)
+		}
+	case []interface{}:
+		for _, e := range v {
+			g.walk(e)
+		}
+	}
+}
+
+func (g *generator) record(name string, data map[string]interface{}) {
+	shapes := g.shapes[name]
+	if shapes == nil {
+		shapes = map[string]*shape{}
+		g.shapes[name] = shapes
+	}
+	for k, v := range data {
+		s := shapes[k]
+		if s == nil {
+			s = &shape{}
+			shapes[k] = s
+		}
+		s.observe(v)
+	}
+}
+
+// addTable records the fields in a field table copied from https://github.com/reddit/reddit/wiki/JSON.
+// Each line holds the type, name and description of a field separated by tabs. Types not used in the
+// wiki tables are mapped to json.RawMessage.
+func (g *generator) addTable(kind string, data []byte) error {
+	name, ok := g.kinds[kind]
+	if !ok {
+		return fmt.Errorf("no struct for kind %s", kind)
+	}
+	declared := g.declared[name]
+	if declared == nil {
+		declared = map[string]string{}
+		g.declared[name] = declared
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		tokens := strings.Split(strings.TrimSpace(line), "\t")
+		if len(tokens) < 2 || tokens[1] == "name" {
+			continue
+		}
+		t, ok := tableTypes[strings.ToLower(tokens[0])]
+		if !ok {
+			t = "json.RawMessage"
+		}
+		declared[tokens[1]] = t
+	}
+	return nil
+}
+
+// newFields returns the fields of the struct name for JSON keys that are not in covered, sorted by key.
+func (g *generator) newFields(name string, covered map[string]bool) []field {
+	keys := map[string]string{}
+	for k, s := range g.shapes[name] {
+		keys[k] = goType(k, s)
+	}
+	for k, t := range g.declared[name] {
+		keys[k] = t
+	}
+	var fields []field
+	for k, t := range keys {
+		if covered[k] {
+			continue
+		}
+		n := goName(k)
+		if n == "" {
+			fmt.Fprintf(os.Stderr, "reddit-gen: skipping %s.%s: not a valid field name\n", name, k)
+			continue
+		}
+		fields = append(fields, field{name: n, typ: t, tag: fmt.Sprintf("`json:%q`", k)})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].tag < fields[j].tag })
+	return fields
+}
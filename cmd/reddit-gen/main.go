@@ -0,0 +1,81 @@
+// Command reddit-gen adds the fields found in captured reddit responses to the Thing data structs in
+// types.go.
+//
+// types.go is maintained by hand. Structs are matched to Things by kind (t1 is Comment, t3 is Link and so
+// on). Existing fields keep their names, types, tags and comments, including fields that are not present
+// in any sample. Every JSON key found in the samples that is not covered by an existing field, or by a
+// struct embedded in it, is added with a type inferred from the values seen. Output is sorted and
+// formatted, so running it twice on the same input is a no-op.
+//
+// Samples are JSON files as returned by reddit. Field tables copied from
+// https://github.com/reddit/reddit/wiki/JSON (type, name and description separated by tabs) may also be
+// provided for a kind with -table. Typical usage, from the root of the package:
+//
+//	go run ./cmd/reddit-gen -samples testdata
+//
+// or go generate, which runs the same command.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"strings"
+)
+
+// kindList is a flag holding kind=value pairs.
+type kindList map[string]string
+
+func (k kindList) String() string { return fmt.Sprint(map[string]string(k)) }
+
+func (k kindList) Set(s string) error {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("expected kind=value, got %s", s)
+	}
+	k[parts[0]] = parts[1]
+	return nil
+}
+
+func main() {
+	var (
+		pkg     = flag.String("pkg", ".", "Directory of the package to generate types for.")
+		out     = flag.String("out", "types.go", "File to generate, relative to -pkg. Its existing declarations are used as overrides.")
+		samples = flag.String("samples", "", "Comma separated list of JSON files or directories of JSON files holding captured responses.")
+		kinds   = kindList{}
+		tables  = kindList{}
+	)
+	for _, k := range defaultKinds {
+		kinds[k.kind] = k.name
+	}
+	flag.Var(kinds, "kind", "Maps a kind to the struct generated for it, as kind=StructName. May be repeated.")
+	flag.Var(tables, "table", "Field table for a kind, as kind=file. May be repeated.")
+	flag.Parse()
+
+	g := newGenerator(kinds)
+	if *samples != "" {
+		for _, s := range strings.Split(*samples, ",") {
+			if err := g.addSamples(s); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+	for kind, file := range tables {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := g.addTable(kind, data); err != nil {
+			log.Fatalf("%s: %v", file, err)
+		}
+	}
+	src, err := g.generate(*pkg, *out)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(*pkg, *out), src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// field is a single field of a generated struct.
+type field struct {
+	name     string // Empty for embedded fields.
+	typ      string
+	tag      string // Including the enclosing backquotes.
+	doc      []string
+	comment  string
+	embedded bool
+}
+
+// structDecl is a struct declared in the package.
+type structDecl struct {
+	file   string
+	doc    []string
+	fields []field
+}
+
+func commentLines(g *ast.CommentGroup) []string {
+	if g == nil {
+		return nil
+	}
+	lines := make([]string, len(g.List))
+	for i, c := range g.List {
+		lines[i] = c.Text
+	}
+	return lines
+}
+
+func exprString(fset *token.FileSet, e ast.Expr) string {
+	var b bytes.Buffer
+	printer.Fprint(&b, fset, e)
+	return b.String()
+}
+
+// jsonKey returns the JSON key of f, or "" if f is embedded or not encoded.
+func (f field) jsonKey() string {
+	if f.embedded {
+		return ""
+	}
+	tag := strings.Split(reflect.StructTag(strings.Trim(f.tag, "`")).Get("json"), ",")[0]
+	switch tag {
+	case "-":
+		return ""
+	case "":
+		return f.name
+	}
+	return tag
+}
+
+// parsePackage returns the structs declared in the non test files of the package in dir, along with the
+// package name and the imports of the file out.
+func parsePackage(dir, out string) (map[string]*structDecl, string, []string, error) {
+	fset := token.NewFileSet()
+	notTest := func(fi os.FileInfo) bool { return !strings.HasSuffix(fi.Name(), "_test.go") }
+	pkgs, err := parser.ParseDir(fset, dir, notTest, parser.ParseComments)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, "", nil, fmt.Errorf("expected a single package in %s, found %d", dir, len(pkgs))
+	}
+	structs, pkgName, imports := map[string]*structDecl{}, "", []string(nil)
+	for name, pkg := range pkgs {
+		pkgName = name
+		for path, f := range pkg.Files {
+			isOut := filepath.Base(path) == out
+			for _, d := range f.Decls {
+				gen, ok := d.(*ast.GenDecl)
+				if !ok || (gen.Tok != token.TYPE && gen.Tok != token.IMPORT) {
+					if isOut {
+						return nil, "", nil, fmt.Errorf("%s may only hold imports and struct declarations", out)
+					}
+					continue
+				}
+				if gen.Tok == token.IMPORT {
+					if isOut {
+						for _, s := range gen.Specs {
+							imports = append(imports, s.(*ast.ImportSpec).Path.Value)
+						}
+					}
+					continue
+				}
+				for _, s := range gen.Specs {
+					spec := s.(*ast.TypeSpec)
+					st, ok := spec.Type.(*ast.StructType)
+					if !ok {
+						if isOut {
+							return nil, "", nil, fmt.Errorf("%s: %s is not a struct", out, spec.Name.Name)
+						}
+						continue
+					}
+					doc := spec.Doc
+					if doc == nil && len(gen.Specs) == 1 {
+						doc = gen.Doc
+					}
+					decl := &structDecl{file: filepath.Base(path), doc: commentLines(doc)}
+					for _, f := range st.Fields.List {
+						fd := field{typ: exprString(fset, f.Type), doc: commentLines(f.Doc)}
+						if f.Tag != nil {
+							fd.tag = f.Tag.Value
+						}
+						if c := commentLines(f.Comment); len(c) > 0 {
+							fd.comment = strings.Join(c, " ")
+						}
+						if len(f.Names) == 0 {
+							fd.embedded = true
+							decl.fields = append(decl.fields, fd)
+							continue
+						}
+						for _, n := range f.Names {
+							fd.name = n.Name
+							decl.fields = append(decl.fields, fd)
+						}
+					}
+					structs[spec.Name.Name] = decl
+				}
+			}
+		}
+	}
+	return structs, pkgName, imports, nil
+}
+
+// coveredKeys adds the JSON keys of the fields of s, including those of embedded structs, to keys.
+func coveredKeys(structs map[string]*structDecl, s *structDecl, keys map[string]bool) {
+	for _, f := range s.fields {
+		if f.embedded {
+			if e, ok := structs[strings.TrimPrefix(f.typ, "*")]; ok {
+				coveredKeys(structs, e, keys)
+			}
+			continue
+		}
+		if k := f.jsonKey(); k != "" {
+			keys[k] = true
+		}
+	}
+}
+
+// sortFields sorts embedded fields first in their existing order, then named fields by name, with Extra last.
+func sortFields(fields []field) {
+	rank := func(f field) int {
+		switch {
+		case f.embedded:
+			return 0
+		case f.name == "Extra":
+			return 2
+		}
+		return 1
+	}
+	sort.SliceStable(fields, func(i, j int) bool {
+		ri, rj := rank(fields[i]), rank(fields[j])
+		if ri != rj || ri != 1 {
+			return ri < rj
+		}
+		return strings.ToLower(fields[i].name) < strings.ToLower(fields[j].name)
+	})
+}
+
+const header = `// This file is maintained by hand. Running go generate adds the fields found in captured reddit responses
+// with reddit-gen. Existing field names, types, tags and comments are kept, so edit them here to override
+// what reddit-gen would infer.`
+
+// generate returns the regenerated contents of the file out in the package in dir.
+func (g *generator) generate(dir, out string) ([]byte, error) {
+	structs, pkgName, imports, err := parsePackage(dir, out)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	generated := map[string]bool{}
+	write := func(name string, s *structDecl) {
+		for _, l := range s.doc {
+			fmt.Fprintln(&b, l)
+		}
+		fmt.Fprintf(&b, "type %s struct {\n", name)
+		for _, f := range s.fields {
+			for _, l := range f.doc {
+				fmt.Fprintln(&b, l)
+			}
+			fmt.Fprintf(&b, "%s %s %s %s\n", f.name, f.typ, f.tag, f.comment)
+		}
+		fmt.Fprint(&b, "}\n\n")
+		generated[name] = true
+	}
+	for _, name := range g.structNames() {
+		s, ok := structs[name]
+		if ok && s.file != out {
+			// Declared by hand elsewhere in the package.
+			continue
+		}
+		if !ok {
+			if len(g.shapes[name]) == 0 && len(g.declared[name]) == 0 {
+				continue
+			}
+			s = &structDecl{doc: []string{fmt.Sprintf("// %s is the data of a Thing of kind %s.", name, g.kindOf(name))}}
+		}
+		covered := map[string]bool{}
+		coveredKeys(structs, s, covered)
+		names := map[string]bool{}
+		for _, f := range s.fields {
+			names[f.name] = true
+		}
+		fields := append([]field(nil), s.fields...)
+		for _, f := range g.newFields(name, covered) {
+			if names[f.name] {
+				fmt.Fprintf(os.Stderr, "reddit-gen: skipping %s.%s: field name already in use\n", name, f.name)
+				continue
+			}
+			fields, names[f.name] = append(fields, f), true
+		}
+		sortFields(fields)
+		write(name, &structDecl{doc: s.doc, fields: fields})
+	}
+	// Keep the structs in out that are not generated from a kind.
+	var rest []string
+	for name, s := range structs {
+		if s.file == out && !generated[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	for _, name := range rest {
+		fields := append([]field(nil), structs[name].fields...)
+		sortFields(fields)
+		write(name, &structDecl{doc: structs[name].doc, fields: fields})
+	}
+
+	body := b.String()
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "%s\n\npackage %s\n\n", header, pkgName)
+	if !containsString(imports, strconv.Quote("encoding/json")) {
+		imports = append(imports, strconv.Quote("encoding/json"))
+	}
+	sort.Strings(imports)
+	var used []string
+	for _, imp := range imports {
+		path, _ := strconv.Unquote(imp)
+		if strings.Contains(body, filepath.Base(path)+".") {
+			used = append(used, imp)
+		}
+	}
+	if len(used) > 0 {
+		fmt.Fprintf(&src, "import (\n%s\n)\n\n", strings.Join(used, "\n"))
+	}
+	src.WriteString(body)
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %v", err)
+	}
+	return formatted, nil
+}
+
+func (g *generator) kindOf(name string) string {
+	var kinds []string
+	for k, n := range g.kinds {
+		if n == name {
+			kinds = append(kinds, k)
+		}
+	}
+	sort.Strings(kinds)
+	return strings.Join(kinds, ", ")
+}
+
+func containsString(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
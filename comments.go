@@ -0,0 +1,63 @@
+package reddit
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-querystring/query"
+)
+
+// Comments is a query for the comments of a Link. It implements URLer. Unloaded comments in the tree are
+// represented by Things holding a More.
+type Comments struct {
+	Link    Fullname    `url:"-"`
+	Comment Fullname    `url:"-"`                 // Only return the subtree of this comment.
+	Context int         `url:"context,omitempty"` // Number of parents of Comment to include.
+	Depth   int         `url:"depth,omitempty"`
+	Limit   int         `url:"limit,omitempty"`
+	Sort    CommentSort `url:"sort,omitempty"`
+}
+
+// URL returns the URL to use when fetching comments.
+func (q *Comments) URL() (string, error) {
+	if err := q.Link.expect(KindLink); err != nil {
+		return "", err
+	}
+	v, err := query.Values(q)
+	if err != nil {
+		return "", err
+	}
+	if q.Comment != "" {
+		if err := q.Comment.expect(KindComment); err != nil {
+			return "", err
+		}
+		v.Set("comment", q.Comment.ID())
+	}
+	return fmt.Sprintf("%s/comments/%s.json?%s", RedditAPIURL, q.Link.ID(), v.Encode()), nil
+}
+
+// Comments fetches a Link and the top level Things of its comment tree. Replies are nested in
+// the Replies of each Comment.
+func (c *Config) Comments(client *http.Client, q *Comments) (*Link, []Thing, error) {
+	u, err := q.URL()
+	if err != nil {
+		return nil, nil, err
+	}
+	var r []Thing
+	if err := c.Get(client, u, &r); err != nil {
+		return nil, nil, err
+	}
+	if len(r) != 2 {
+		return nil, nil, fmt.Errorf("expected 2 listings in response from %s, got %d", u, len(r))
+	}
+	links, lok := r[0].Data.(*Listing)
+	comments, cok := r[1].Data.(*Listing)
+	if !lok || !cok || len(links.Children) != 1 {
+		return nil, nil, fmt.Errorf("unexpected response from %s", u)
+	}
+	link, ok := links.Children[0].Data.(*Link)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected %s in response from %s", links.Children[0].Kind, u)
+	}
+	return link, comments.Children, nil
+}
@@ -0,0 +1,366 @@
+package reddit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DecodeOptions control how responses from reddit are decoded. Thing.UnmarshalJSON uses the zero value. Use
+// WithDecodeOptions to decode the responses to a Config with other options, or DecodeOptions.Unmarshal to
+// decode other data.
+type DecodeOptions struct {
+	// KeepUnknownKinds keeps Things of kinds without a decoder instead of failing. The Data of such a Thing
+	// is the json.RawMessage sent by reddit.
+	KeepUnknownKinds bool
+	// Lenient converts fields sent with an unexpected JSON type where possible, for example a number sent as
+	// a string. Fields that cannot be converted are left as the zero value instead of failing the decode.
+	Lenient bool
+	// Kinds holds decoders for Things of the provided kinds, used in place of those registered with
+	// RegisterKind. Each must return a pointer to a new value to decode the Data of a Thing into.
+	Kinds map[string]func() interface{}
+}
+
+var (
+	kindsMu      sync.RWMutex
+	kindDecoders = map[string]func() interface{}{
+		"Listing":            func() interface{} { return &Listing{} },
+		"t1":                 func() interface{} { return &Comment{} },
+		"t2":                 func() interface{} { return &Account{} },
+		"t3":                 func() interface{} { return &Link{} },
+		"t4":                 func() interface{} { return &Message{} },
+		"t5":                 func() interface{} { return &SubReddit{} },
+		"more":               func() interface{} { return &More{} },
+		"modaction":          func() interface{} { return &ModAction{} },
+		"wikipage":           func() interface{} { return &WikiPage{} },
+		"wikipagesettings":   func() interface{} { return &WikiPageSettings{} },
+		"subreddit_settings": func() interface{} { return &SubRedditSettings{} },
+		"LabeledMulti":       func() interface{} { return &LabeledMulti{} },
+	}
+)
+
+// RegisterKind registers the default decoder for Things of the provided kind. newData must return a pointer
+// to a new value to decode the Data of the Thing into. Registering a kind that already has a decoder replaces
+// it, which allows the types used for built in kinds to be overridden. This affects every user of the package
+// in the process, so libraries should use DecodeOptions.Kinds instead.
+func RegisterKind(kind string, newData func() interface{}) {
+	kindsMu.Lock()
+	defer kindsMu.Unlock()
+	kindDecoders[kind] = newData
+}
+
+// kindDecoder returns the decoder registered for kind.
+func kindDecoder(kind string) func() interface{} {
+	kindsMu.RLock()
+	defer kindsMu.RUnlock()
+	return kindDecoders[kind]
+}
+
+// kindDecoder returns the decoder for kind, preferring o.Kinds to the registered decoders.
+func (o DecodeOptions) kindDecoder(kind string) func() interface{} {
+	if newData, ok := o.Kinds[kind]; ok {
+		return newData
+	}
+	return kindDecoder(kind)
+}
+
+func (o DecodeOptions) isZero() bool { return !o.KeepUnknownKinds && !o.Lenient && len(o.Kinds) == 0 }
+
+// Unmarshal decodes data into v like json.Unmarshal, decoding the Things within v with o.
+func (o DecodeOptions) Unmarshal(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if o.isZero() || rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return json.Unmarshal(data, v)
+	}
+	return o.decode(data, rv.Elem(), false)
+}
+
+// decodeData decodes data into val, which must be a pointer. If o.Lenient is set and data cannot be decoded
+// as is, fields are decoded one by one and converted where necessary.
+func (o DecodeOptions) decodeData(data []byte, val interface{}) error {
+	v := reflect.ValueOf(val).Elem()
+	if err := o.decode(data, v, false); err != nil {
+		if !o.Lenient {
+			return err
+		}
+		v.Set(reflect.Zero(v.Type()))
+		o.decode(data, v, true)
+	}
+	return nil
+}
+
+// decode decodes data into v. encoding/json cannot pass o on to Thing.UnmarshalJSON, so values that hold
+// Things are decoded field by field and their Things decoded with o. If lenient is set errors are ignored,
+// and values that cannot be decoded are converted or left as the zero value.
+func (o DecodeOptions) decode(data []byte, v reflect.Value, lenient bool) error {
+	t := v.Type()
+	switch {
+	case !lenient && (o.isZero() || !holdsThings(t)):
+		return json.Unmarshal(data, v.Addr().Interface())
+	case lenient && !holdsThings(t):
+		decodeLenient(data, v)
+		return nil
+	case string(bytes.TrimSpace(data)) == "null":
+		v.Set(reflect.Zero(t))
+		return nil
+	case t == thingType:
+		err := o.decodeThing(data, v.Addr().Interface().(*Thing))
+		if err != nil && lenient {
+			v.Set(reflect.Zero(t))
+			return nil
+		}
+		return err
+	case t == repliesType:
+		err := o.decodeReplies(data, v)
+		if err != nil && lenient {
+			v.Set(reflect.Zero(t))
+			return nil
+		}
+		return err
+	}
+
+	var err error
+	keep := func(e error) {
+		if err == nil && !lenient {
+			err = e
+		}
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(t.Elem())
+		keep(o.decode(data, elem.Elem(), lenient))
+		v.Set(elem)
+	case reflect.Struct:
+		var raw map[string]json.RawMessage
+		if e := json.Unmarshal(data, &raw); e != nil {
+			keep(e)
+			return err
+		}
+		fields := map[string]reflect.Value{}
+		jsonFields(v, fields)
+		for name, f := range fields {
+			if data, ok := raw[name]; ok {
+				keep(o.decode(data, f, lenient))
+			}
+		}
+		fillExtra(data, v)
+	case reflect.Slice:
+		var raw []json.RawMessage
+		if e := json.Unmarshal(data, &raw); e != nil {
+			keep(e)
+			return err
+		}
+		s := reflect.MakeSlice(t, len(raw), len(raw))
+		for i, data := range raw {
+			keep(o.decode(data, s.Index(i), lenient))
+		}
+		v.Set(s)
+	case reflect.Map:
+		var raw map[string]json.RawMessage
+		if e := json.Unmarshal(data, &raw); e != nil {
+			keep(e)
+			return err
+		}
+		m := reflect.MakeMapWithSize(t, len(raw))
+		for k, data := range raw {
+			elem := reflect.New(t.Elem()).Elem()
+			keep(o.decode(data, elem, lenient))
+			m.SetMapIndex(reflect.ValueOf(k).Convert(t.Key()), elem)
+		}
+		v.Set(m)
+	}
+	return err
+}
+
+// decodeReplies decodes data into v, a Replies, like Replies.UnmarshalJSON but with o.
+func (o DecodeOptions) decodeReplies(data []byte, v reflect.Value) error {
+	if s := string(bytes.TrimSpace(data)); s == `""` {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	var t Thing
+	if err := o.decodeThing(data, &t); err != nil {
+		return err
+	}
+	l, ok := t.Data.(*Listing)
+	if !ok {
+		return fmt.Errorf("expected Listing for replies, got %s", t.Kind)
+	}
+	v.Set(reflect.ValueOf(Replies(l.Children)))
+	return nil
+}
+
+var thingHolders sync.Map
+
+// holdsThings returns true if values of type t may hold Things that are decoded by decode. Types that decode
+// themselves are opaque, except for Thing, Replies and types that only do so to fill an Extra field.
+func holdsThings(t reflect.Type) bool {
+	if h, ok := thingHolders.Load(t); ok {
+		return h.(bool)
+	}
+	h := mayHoldThings(t, map[reflect.Type]bool{})
+	thingHolders.Store(t, h)
+	return h
+}
+
+func mayHoldThings(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if t == thingType || t == repliesType {
+		return true
+	}
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	if reflect.PtrTo(t).Implements(unmarshalerType) && !hasExtra(reflect.New(t).Elem()) {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice:
+		return mayHoldThings(t.Elem(), seen)
+	case reflect.Map:
+		return t.Key().Kind() == reflect.String && mayHoldThings(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath == "" && f.Tag.Get("json") != "-" && mayHoldThings(f.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// decodeLenient decodes data into v, leaving any part of v that cannot be decoded as the zero value.
+func decodeLenient(data []byte, v reflect.Value) {
+	if json.Unmarshal(data, v.Addr().Interface()) == nil {
+		return
+	}
+	v.Set(reflect.Zero(v.Type()))
+	if converted, ok := convertScalar(data, v.Type()); ok && json.Unmarshal(converted, v.Addr().Interface()) == nil {
+		return
+	}
+	v.Set(reflect.Zero(v.Type()))
+	if v.Addr().Type().Implements(unmarshalerType) && !hasExtra(v) {
+		// The type decodes itself and there is no way to partially decode it. Types with an Extra field
+		// only decode themselves to fill it, so they are decoded field by field below.
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(v.Type().Elem())
+		decodeLenient(data, elem.Elem())
+		if !elem.Elem().IsZero() {
+			v.Set(elem)
+		}
+	case reflect.Struct:
+		var raw map[string]json.RawMessage
+		if json.Unmarshal(data, &raw) != nil {
+			return
+		}
+		fields := map[string]reflect.Value{}
+		jsonFields(v, fields)
+		for name, f := range fields {
+			if data, ok := raw[name]; ok {
+				decodeLenient(data, f)
+			}
+		}
+		fillExtra(data, v)
+	case reflect.Slice:
+		var raw []json.RawMessage
+		if json.Unmarshal(data, &raw) != nil {
+			return
+		}
+		s := reflect.MakeSlice(v.Type(), len(raw), len(raw))
+		for i, data := range raw {
+			decodeLenient(data, s.Index(i))
+		}
+		v.Set(s)
+	}
+}
+
+// jsonFields adds the settable fields of the struct v to fields, keyed by their JSON name. Fields of embedded
+// structs are added unless a field with the same name is present in the outer struct.
+func jsonFields(v reflect.Value, fields map[string]reflect.Value) {
+	t := v.Type()
+	var embedded []reflect.Value
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if f.PkgPath != "" || tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			embedded = append(embedded, v.Field(i))
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if _, ok := fields[name]; !ok {
+			fields[name] = v.Field(i)
+		}
+	}
+	for _, e := range embedded {
+		jsonFields(e, fields)
+	}
+}
+
+// convertScalar converts a JSON scalar to the JSON representation expected for values of type t. It returns false
+// if no conversion is possible.
+func convertScalar(data []byte, t reflect.Type) ([]byte, bool) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] == '{' || data[0] == '[' || string(data) == "null" {
+		return nil, false
+	}
+	s := string(data)
+	if data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, false
+		}
+		s = strings.TrimSpace(s)
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return []byte(strconv.Quote(s)), true
+	case reflect.Bool:
+		switch s {
+		case "true", "1":
+			return []byte("true"), true
+		case "false", "0", "":
+			return []byte("false"), true
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return []byte(strconv.FormatBool(f != 0)), true
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch s {
+		case "true":
+			return []byte("1"), true
+		case "false", "":
+			return []byte("0"), true
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil && f == float64(int64(f)) {
+			return []byte(strconv.FormatInt(int64(f), 10)), true
+		}
+	case reflect.Float32, reflect.Float64:
+		switch s {
+		case "true":
+			return []byte("1"), true
+		case "false", "":
+			return []byte("0"), true
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return []byte(strconv.FormatFloat(f, 'g', -1, 64)), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,121 @@
+package reddit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const unknownKindListing = `{"kind": "Listing", "data": {"children": [
+	{"kind": "t3", "data": {"id": "abc", "title": "Known"}},
+	{"kind": "t9", "data": {"id": "xyz", "something": "new"}}
+]}}`
+
+func TestThing_UnknownKinds(t *testing.T) {
+	require := require.New(t)
+
+	var thing Thing
+	require.EqualError(json.Unmarshal([]byte(unknownKindListing), &thing), "unsupported kind: t9")
+
+	require.NoError(DecodeOptions{KeepUnknownKinds: true}.Unmarshal([]byte(unknownKindListing), &thing))
+	children := thing.Data.(*Listing).Children
+	require.Len(children, 2)
+	require.Equal("Known", children[0].Data.(*Link).Title)
+	require.Equal("t9", children[1].Kind)
+	require.JSONEq(`{"id": "xyz", "something": "new"}`, string(children[1].Data.(json.RawMessage)))
+
+	// Options only apply to the decode they are used for.
+	require.EqualError(json.Unmarshal([]byte(unknownKindListing), &thing), "unsupported kind: t9")
+}
+
+func TestDecodeOptions_Nested(t *testing.T) {
+	require := require.New(t)
+
+	page := `[
+		{"kind": "Listing", "data": {"children": [{"kind": "t3", "data": {"id": "abc"}}]}},
+		{"kind": "Listing", "data": {"children": [{"kind": "t1", "data": {"id": "def", "body": "Hi", "score": "7", "new_field": 1, "replies":
+			{"kind": "Listing", "data": {"children": [{"kind": "t9", "data": {"id": "ghi"}}]}}}}]}}
+	]`
+	var things []Thing
+	require.Error(json.Unmarshal([]byte(page), &things))
+	opts := DecodeOptions{KeepUnknownKinds: true, Lenient: true, Kinds: map[string]func() interface{}{
+		"t3": func() interface{} { return &customKind{} },
+	}}
+	require.NoError(opts.Unmarshal([]byte(page), &things))
+	require.Len(things, 2)
+	require.Equal(&customKind{}, things[0].Data.(*Listing).Children[0].Data)
+	c := things[1].Data.(*Listing).Children[0].Data.(*Comment)
+	require.Equal("Hi", c.Body)
+	require.Equal(7, c.Score)
+	require.Equal(map[string]json.RawMessage{"new_field": json.RawMessage(`1`)}, c.Extra)
+	require.Len(c.Replies, 1)
+	require.Equal("t9", c.Replies[0].Kind)
+	require.JSONEq(`{"id": "ghi"}`, string(c.Replies[0].Data.(json.RawMessage)))
+}
+
+func TestConfig_DecodeOptions(t *testing.T) {
+	require := require.New(t)
+
+	const url = "https://oauth.reddit.com/r/golang/new.json"
+	m := mock(get(url, unknownKindListing), get(url, unknownKindListing))
+	defer m.reset()
+
+	var thing Thing
+	keep := authedConfig(m).Configure(WithDecodeOptions(DecodeOptions{KeepUnknownKinds: true}))
+	require.NoError(keep.Get(nil, url, &thing))
+	require.Equal("t9", thing.Data.(*Listing).Children[1].Kind)
+	require.EqualError(authedConfig(m).Get(nil, url, &thing), "failed to parse response from "+url+": unsupported kind: t9")
+}
+
+type customKind struct {
+	Something string `json:"something"`
+}
+
+func TestRegisterKind(t *testing.T) {
+	RegisterKind("t9", func() interface{} { return &customKind{} })
+	defer func() {
+		kindsMu.Lock()
+		delete(kindDecoders, "t9")
+		kindsMu.Unlock()
+	}()
+
+	var thing Thing
+	require.NoError(t, json.Unmarshal([]byte(unknownKindListing), &thing))
+	require.Equal(t, &customKind{Something: "new"}, thing.Data.(*Listing).Children[1].Data)
+}
+
+const mistypedLink = `{"kind": "t3", "data": {
+	"id": "abc",
+	"title": 1234,
+	"score": "42",
+	"num_comments": 7.0,
+	"over_18": 1,
+	"ups": "1.5",
+	"edited": "yesterday",
+	"created_utc": "1500000000.5",
+	"stickied": "true",
+	"is_self": {"unexpected": true},
+	"author": "gopher"
+}}`
+
+func TestThing_LenientDecoding(t *testing.T) {
+	require := require.New(t)
+
+	var thing Thing
+	require.Error(json.Unmarshal([]byte(mistypedLink), &thing))
+
+	require.NoError(DecodeOptions{Lenient: true}.Unmarshal([]byte(mistypedLink), &thing))
+	l := thing.Data.(*Link)
+	require.Equal(Fullname("t3_abc"), thing.Name)
+	require.Equal("1234", l.Title)
+	require.Equal(42, l.Score)
+	require.Equal(7, l.NumComments)
+	require.True(l.Over18)
+	require.Equal(0, l.Ups)
+	require.Equal(Edited{}, l.Edited)
+	require.Equal(Timestamp(1500000000.5), l.CreatedUTC)
+	require.True(l.Stickied)
+	require.False(l.IsSelf)
+	require.Equal("gopher", l.Author)
+}
@@ -15,7 +15,11 @@
 //  * Code to save and load authorization credentials (client id, client secret, etc).
 //  * A simple API to obtain and store an OAuth token for a script app using these credentials.
 //  * An API to perform GET requests using the obtained token.
-//  * An API to stream listings.
+//  * An API to perform POST requests and moderation actions (approve, remove, lock, ban, etc).
+//  * An API to stream listings, including moderation queues and logs.
+//  * Batched lookups of fullnames via /api/info that respect reddit's rate limits.
 //
 // Please see the package examples for details on how to use the above functionality.
 package reddit
+
+//go:generate go run ./cmd/reddit-gen -samples testdata
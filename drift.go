@@ -0,0 +1,161 @@
+package reddit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// DriftReport records the differences between JSON responses from reddit and the types in this
+// package. Unlike Thing.UnmarshalJSON it is strict: fields only preserved in Extra count as missing,
+// and no lenient conversions are attempted. Paths are of the form Type.field.nested_field, with []
+// for slice elements and * for map values.
+type DriftReport struct {
+	Things       map[string]int // Number of Things checked, by kind.
+	UnknownKinds map[string]int // Number of Things with no registered decoder, by kind.
+	Missing      map[string]int // Number of JSON fields with no struct field, by path.
+	Mismatched   map[string]int // Number of JSON fields that cannot be decoded into their struct field, by path and types.
+}
+
+// NewDriftReport returns an empty DriftReport.
+func NewDriftReport() *DriftReport {
+	return &DriftReport{Things: map[string]int{}, UnknownKinds: map[string]int{}, Missing: map[string]int{}, Mismatched: map[string]int{}}
+}
+
+// HasDrift returns true if any missing fields, type mismatches or unknown kinds were found.
+func (r *DriftReport) HasDrift() bool {
+	return len(r.Missing) > 0 || len(r.Mismatched) > 0 || len(r.UnknownKinds) > 0
+}
+
+// Add checks every Thing in the JSON response data, at any depth, against the type registered for its kind.
+func (r *DriftReport) Add(data []byte) error {
+	if !json.Valid(data) {
+		return fmt.Errorf("invalid JSON")
+	}
+	r.walk(data)
+	return nil
+}
+
+func (r *DriftReport) walk(data json.RawMessage) {
+	switch jsonType(data) {
+	case "object":
+		var m map[string]json.RawMessage
+		json.Unmarshal(data, &m)
+		var kind string
+		if _, ok := m["data"]; ok && json.Unmarshal(m["kind"], &kind) == nil && kind != "" {
+			r.checkThing(kind, m["data"])
+		}
+		for _, v := range m {
+			r.walk(v)
+		}
+	case "array":
+		var a []json.RawMessage
+		json.Unmarshal(data, &a)
+		for _, v := range a {
+			r.walk(v)
+		}
+	}
+}
+
+func (r *DriftReport) checkThing(kind string, data json.RawMessage) {
+	newData := kindDecoder(kind)
+	if newData == nil {
+		r.UnknownKinds[kind]++
+		return
+	}
+	r.Things[kind]++
+	t := reflect.TypeOf(newData())
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	r.check(t.Name(), t, data)
+}
+
+var (
+	thingType   = reflect.TypeOf(Thing{})
+	repliesType = reflect.TypeOf(Replies{})
+)
+
+// check compares data with the type t, recording missing fields and mismatched types under path.
+func (r *DriftReport) check(path string, t reflect.Type, data json.RawMessage) {
+	if jsonType(data) == "null" {
+		return
+	}
+	mismatch := func() { r.Mismatched[fmt.Sprintf("%s: %s into %s", path, jsonType(data), t)]++ }
+	switch {
+	case t == thingType || t == repliesType:
+		// Things are checked when they are reached by walk.
+		return
+	case reflect.PtrTo(t).Implements(unmarshalerType) && !hasExtra(reflect.New(t).Elem()):
+		if json.Unmarshal(data, reflect.New(t).Interface()) != nil {
+			mismatch()
+		}
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		r.check(path, t.Elem(), data)
+	case reflect.Interface:
+	case reflect.Struct:
+		var m map[string]json.RawMessage
+		if json.Unmarshal(data, &m) != nil {
+			mismatch()
+			return
+		}
+		fields := map[string]reflect.Value{}
+		jsonFields(reflect.New(t).Elem(), fields)
+		for k, v := range m {
+			f, ok := fields[k]
+			if !ok {
+				r.Missing[path+"."+k]++
+				continue
+			}
+			r.check(path+"."+k, f.Type(), v)
+		}
+	case reflect.Slice, reflect.Array:
+		var a []json.RawMessage
+		if json.Unmarshal(data, &a) != nil {
+			mismatch()
+			return
+		}
+		for _, v := range a {
+			r.check(path+"[]", t.Elem(), v)
+		}
+	case reflect.Map:
+		var m map[string]json.RawMessage
+		if json.Unmarshal(data, &m) != nil {
+			mismatch()
+			return
+		}
+		for _, v := range m {
+			r.check(path+".*", t.Elem(), v)
+		}
+	default:
+		if json.Unmarshal(data, reflect.New(t).Interface()) != nil {
+			mismatch()
+		}
+	}
+}
+
+// jsonType returns the JSON type of data.
+func jsonType(data []byte) string {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return "empty"
+	}
+	switch data[0] {
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case '"':
+		return "string"
+	case 't', 'f':
+		return "bool"
+	case 'n':
+		return "null"
+	}
+	return "number"
+}
@@ -0,0 +1,65 @@
+package reddit
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const driftListing = `{"kind": "Listing", "data": {"children": [
+	{"kind": "t3", "data": {
+		"id": "a", "title": "A", "score": "12", "brand_new": true,
+		"preview": {"enabled": true, "images": [{"source": {"url": "u", "width": 1, "height": 1, "format": "png"}}]},
+		"media_metadata": {"x": {"status": "valid", "e": "Image", "q": 1}, "y": {"status": "valid", "e": 1}}
+	}},
+	{"kind": "t3", "data": {"id": "b", "title": "B", "score": 3, "brand_new": false, "likes": "yes"}},
+	{"kind": "t1", "data": {"id": "c", "replies": {"kind": "Listing", "data": {"children": [
+		{"kind": "t1", "data": {"id": "d", "replies": "", "gilded": 1.5}}
+	]}}}},
+	{"kind": "t9", "data": {}}
+]}}`
+
+func TestDriftReport(t *testing.T) {
+	require := require.New(t)
+
+	r := NewDriftReport()
+	require.False(r.HasDrift())
+	require.NoError(r.Add([]byte(topPostsBody(0, 2))))
+	require.False(r.HasDrift())
+	require.EqualError(r.Add([]byte(`{"kind"`)), "invalid JSON")
+
+	r = NewDriftReport()
+	require.NoError(r.Add([]byte(driftListing)))
+	require.True(r.HasDrift())
+	require.Equal(map[string]int{"Listing": 2, "t1": 2, "t3": 2}, r.Things)
+	require.Equal(map[string]int{"t9": 1}, r.UnknownKinds)
+	require.Equal(map[string]int{
+		"Link.brand_new":                      2,
+		"Link.preview.images[].source.format": 1,
+		"Link.media_metadata.*.q":             1,
+	}, r.Missing)
+	require.Equal(map[string]int{
+		"Link.score: string into int":                 1,
+		"Link.likes: string into reddit.Vote":         1,
+		"Link.media_metadata.*.e: number into string": 1,
+		"Comment.gilded: number into int":             1,
+	}, r.Mismatched)
+}
+
+// TestDriftReport_Testdata keeps the data model in step with the captured responses in testdata.
+func TestDriftReport_Testdata(t *testing.T) {
+	require := require.New(t)
+	files, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	require.NoError(err)
+	require.NotEmpty(files)
+
+	r := NewDriftReport()
+	for _, f := range files {
+		data, err := ioutil.ReadFile(f)
+		require.NoError(err)
+		require.NoError(r.Add(data), f)
+	}
+	require.False(r.HasDrift(), "missing: %v, unknown kinds: %v, mismatched: %v", r.Missing, r.UnknownKinds, r.Mismatched)
+}
@@ -0,0 +1,155 @@
+package reddit
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Emoji is an emoji that may be used in richtext flair.
+type Emoji struct {
+	Name             string   `json:"-"`
+	URL              string   `json:"url"`
+	CreatedBy        Fullname `json:"created_by"`
+	ModFlairOnly     bool     `json:"mod_flair_only"`
+	PostFlairAllowed bool     `json:"post_flair_allowed"`
+	UserFlairAllowed bool     `json:"user_flair_allowed"`
+}
+
+// Alias returns the alias used to refer to e in richtext, for example ":gopher:".
+func (e Emoji) Alias() string { return ":" + e.Name + ":" }
+
+// Emojis holds the emoji available in a subreddit, sorted by name.
+type Emojis struct {
+	Snoomojis []Emoji // Emoji available in all subreddits.
+	SubReddit []Emoji // Emoji uploaded to the subreddit.
+}
+
+func emojiURL(subreddit, endpoint string) string {
+	return fmt.Sprintf("%s/api/v1/%s/%s", RedditAPIURL, subreddit, endpoint)
+}
+
+func sortedEmojis(m map[string]Emoji) []Emoji {
+	emojis := make([]Emoji, 0, len(m))
+	for name, e := range m {
+		e.Name = name
+		emojis = append(emojis, e)
+	}
+	sort.Slice(emojis, func(i, j int) bool { return emojis[i].Name < emojis[j].Name })
+	return emojis
+}
+
+// SubRedditEmojis returns the emoji available in subreddit.
+func (c *Config) SubRedditEmojis(client *http.Client, subreddit string) (*Emojis, error) {
+	// reddit keys the subreddit emoji by the fullname of the subreddit.
+	var r map[string]map[string]Emoji
+	if err := c.Get(client, emojiURL(subreddit, "emojis/all"), &r); err != nil {
+		return nil, err
+	}
+	emojis := &Emojis{Snoomojis: sortedEmojis(r["snoomojis"])}
+	for k, m := range r {
+		if k != "snoomojis" {
+			emojis.SubReddit = append(emojis.SubReddit, sortedEmojis(m)...)
+		}
+	}
+	return emojis, nil
+}
+
+// EmojiUpload holds an emoji image to upload to a subreddit. Image must be a PNG or JPEG of at
+// most 128x128 pixels.
+type EmojiUpload struct {
+	Name             string
+	Filename         string
+	MimeType         string // image/png or image/jpeg
+	Image            io.Reader
+	ModFlairOnly     bool
+	PostFlairAllowed bool
+	UserFlairAllowed bool
+}
+
+type uploadLease struct {
+	Action string `json:"action"`
+	Fields []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"fields"`
+}
+
+// UploadEmoji adds an emoji to subreddit. The image is uploaded to the storage location leased by
+// reddit before the emoji is created.
+func (c *Config) UploadEmoji(client *http.Client, subreddit string, e EmojiUpload) error {
+	var lease struct {
+		Lease uploadLease `json:"s3UploadLease"`
+	}
+	form := url.Values{"filepath": {e.Filename}, "mimetype": {e.MimeType}}
+	if err := c.Post(client, emojiURL(subreddit, "emoji_asset_upload_s3.json"), form, &lease); err != nil {
+		return err
+	}
+	key, err := c.upload(client, lease.Lease, e)
+	if err != nil {
+		return err
+	}
+	form = url.Values{
+		"name":               {e.Name},
+		"s3_key":             {key},
+		"mod_flair_only":     {strconv.FormatBool(e.ModFlairOnly)},
+		"post_flair_allowed": {strconv.FormatBool(e.PostFlairAllowed)},
+		"user_flair_allowed": {strconv.FormatBool(e.UserFlairAllowed)},
+	}
+	return c.Post(client, emojiURL(subreddit, "emoji.json"), form, nil)
+}
+
+// upload sends the image in e to the location in lease and returns the key it was stored under.
+func (c *Config) upload(client *http.Client, lease uploadLease, e EmojiUpload) (string, error) {
+	var body bytes.Buffer
+	w, key := multipart.NewWriter(&body), ""
+	for _, f := range lease.Fields {
+		if f.Name == "key" {
+			key = f.Value
+		}
+		if err := w.WriteField(f.Name, f.Value); err != nil {
+			return "", err
+		}
+	}
+	part, err := w.CreateFormFile("file", e.Filename)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(part, e.Image); err != nil {
+		return "", fmt.Errorf("failed to read emoji image: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	action := lease.Action
+	if strings.HasPrefix(action, "//") {
+		action = "https:" + action
+	}
+	req, err := http.NewRequest(http.MethodPost, action, &body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request for %s: %v", action, err)
+	}
+	req.Header.Add("Content-Type", w.FormDataContentType())
+	_, err = c.httpRequest(client, RequestInfo{Request: req}, func(info *RequestInfo) error {
+		if info.Response.StatusCode < 200 || info.Response.StatusCode > 299 {
+			return &StatusError{StatusCode: info.Response.StatusCode, URL: action, Body: info.Body}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// DeleteEmoji deletes the emoji with the provided name from subreddit.
+func (c *Config) DeleteEmoji(client *http.Client, subreddit, name string) error {
+	return c.do(client, http.MethodDelete, emojiURL(subreddit, "emoji/"+url.PathEscape(name)), nil, nil)
+}
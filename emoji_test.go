@@ -0,0 +1,62 @@
+package reddit
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const emojisBody = `{
+	"snoomojis": {
+		"snoo": {"url": "https://emoji.redditmedia.com/snoo.png", "user_flair_allowed": true, "post_flair_allowed": true, "mod_flair_only": false, "created_by": "t2_1"},
+		"cake": {"url": "https://emoji.redditmedia.com/cake.png", "user_flair_allowed": true, "post_flair_allowed": true, "mod_flair_only": false, "created_by": "t2_1"}
+	},
+	"t5_2rc7j": {
+		"gopher": {"url": "https://emoji.redditmedia.com/gopher.png", "user_flair_allowed": true, "post_flair_allowed": false, "mod_flair_only": true, "created_by": "t2_abc"}
+	}
+}`
+
+func TestConfig_SubRedditEmojis(t *testing.T) {
+	m := mock(get("https://oauth.reddit.com/api/v1/golang/emojis/all", emojisBody))
+	defer m.reset()
+
+	require := require.New(t)
+	emojis, err := authedConfig(m).SubRedditEmojis(nil, "golang")
+	require.NoError(err)
+	require.Equal([]string{"cake", "snoo"}, []string{emojis.Snoomojis[0].Name, emojis.Snoomojis[1].Name})
+	require.Equal([]Emoji{{
+		Name: "gopher", URL: "https://emoji.redditmedia.com/gopher.png", CreatedBy: "t2_abc",
+		ModFlairOnly: true, UserFlairAllowed: true,
+	}}, emojis.SubReddit)
+	require.Equal(":gopher:", emojis.SubReddit[0].Alias())
+}
+
+func TestConfig_UploadEmoji(t *testing.T) {
+	m := mock(
+		post("https://oauth.reddit.com/api/v1/golang/emoji_asset_upload_s3.json", "filepath=gopher.png&mimetype=image%2Fpng",
+			`{"s3UploadLease": {"action": "//reddit-subreddit-emoji.s3-accelerate.amazonaws.com", "fields": [
+				{"name": "acl", "value": "private"},
+				{"name": "key", "value": "t5_2rc7j/gopher-key"}
+			]}}`),
+		response{
+			method:       http.MethodPost,
+			statusCode:   201,
+			requestURL:   "https://reddit-subreddit-emoji.s3-accelerate.amazonaws.com",
+			bodyContains: []string{"t5_2rc7j/gopher-key", `filename="gopher.png"`, "PNGDATA"},
+		},
+		post("https://oauth.reddit.com/api/v1/golang/emoji.json",
+			"mod_flair_only=false&name=gopher&post_flair_allowed=true&s3_key=t5_2rc7j%2Fgopher-key&user_flair_allowed=true", `{}`),
+		del("https://oauth.reddit.com/api/v1/golang/emoji/gopher", `{}`),
+	)
+	defer m.reset()
+
+	require := require.New(t)
+	c := authedConfig(m)
+	require.NoError(c.UploadEmoji(nil, "golang", EmojiUpload{
+		Name: "gopher", Filename: "gopher.png", MimeType: "image/png", Image: strings.NewReader("PNGDATA"),
+		PostFlairAllowed: true, UserFlairAllowed: true,
+	}))
+	require.NoError(c.DeleteEmoji(nil, "golang", "gopher"))
+}
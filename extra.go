@@ -0,0 +1,144 @@
+package reddit
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+var extraType = reflect.TypeOf(map[string]json.RawMessage(nil))
+
+// fillExtra stores the fields of data that the struct v has no field for in v.Extra. It does nothing if v
+// is not a struct with an Extra field. Values are compacted so that re-encoding v is deterministic.
+func fillExtra(data []byte, v reflect.Value) {
+	if !hasExtra(v) {
+		return
+	}
+	extra := v.FieldByName("Extra")
+	var raw map[string]json.RawMessage
+	if json.Unmarshal(data, &raw) != nil {
+		return
+	}
+	fields := map[string]reflect.Value{}
+	jsonFields(v, fields)
+	m := map[string]json.RawMessage{}
+	for name, val := range raw {
+		if _, ok := fields[name]; ok {
+			continue
+		}
+		var b bytes.Buffer
+		if json.Compact(&b, val) != nil {
+			continue
+		}
+		m[name] = b.Bytes()
+	}
+	if len(m) > 0 {
+		extra.Set(reflect.ValueOf(m))
+	}
+}
+
+// hasExtra returns true if v is a struct with an Extra field for unknown fields.
+func hasExtra(v reflect.Value) bool {
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	extra := v.FieldByName("Extra")
+	return extra.IsValid() && extra.Type() == extraType
+}
+
+// unmarshalExtra decodes data into alias, a pointer to v converted to a type without methods, and fills the
+// Extra field of v. Type errors name the type of v rather than that of alias.
+func unmarshalExtra(data []byte, v, alias interface{}) error {
+	if err := json.Unmarshal(data, alias); err != nil {
+		if e, ok := err.(*json.UnmarshalTypeError); ok {
+			from, to := reflect.TypeOf(alias).Elem(), reflect.TypeOf(v).Elem()
+			if e.Type == from {
+				e.Type = to
+			}
+			if e.Struct == from.Name() {
+				e.Struct = to.Name()
+			}
+		}
+		return err
+	}
+	fillExtra(data, reflect.ValueOf(v).Elem())
+	return nil
+}
+
+// marshalExtra marshals v, adding the fields in extra that are not already present.
+func marshalExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name, val := range extra {
+		if _, ok := fields[name]; !ok {
+			fields[name] = val
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// MarshalJSON implements json.Marshaler for Link. Fields in Extra are included in the output.
+func (l Link) MarshalJSON() ([]byte, error) {
+	type link Link
+	return marshalExtra(link(l), l.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler for Link. Fields that Link has no field for are stored in Extra.
+func (l *Link) UnmarshalJSON(data []byte) error {
+	type link Link
+	return unmarshalExtra(data, l, (*link)(l))
+}
+
+// MarshalJSON implements json.Marshaler for Comment. Fields in Extra are included in the output.
+func (c Comment) MarshalJSON() ([]byte, error) {
+	type comment Comment
+	return marshalExtra(comment(c), c.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler for Comment. Fields that Comment has no field for are stored in Extra.
+func (c *Comment) UnmarshalJSON(data []byte) error {
+	type comment Comment
+	return unmarshalExtra(data, c, (*comment)(c))
+}
+
+// MarshalJSON implements json.Marshaler for SubReddit. Fields in Extra are included in the output.
+func (s SubReddit) MarshalJSON() ([]byte, error) {
+	type subReddit SubReddit
+	return marshalExtra(subReddit(s), s.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler for SubReddit. Fields that SubReddit has no field for are stored in Extra.
+func (s *SubReddit) UnmarshalJSON(data []byte) error {
+	type subReddit SubReddit
+	return unmarshalExtra(data, s, (*subReddit)(s))
+}
+
+// MarshalJSON implements json.Marshaler for Account. Fields in Extra are included in the output.
+func (a Account) MarshalJSON() ([]byte, error) {
+	type account Account
+	return marshalExtra(account(a), a.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler for Account. Fields that Account has no field for are stored in Extra.
+func (a *Account) UnmarshalJSON(data []byte) error {
+	type account Account
+	return unmarshalExtra(data, a, (*account)(a))
+}
+
+// MarshalJSON implements json.Marshaler for Message. Fields in Extra are included in the output.
+func (m Message) MarshalJSON() ([]byte, error) {
+	type message Message
+	return marshalExtra(message(m), m.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler for Message. Fields that Message has no field for are stored in Extra.
+func (m *Message) UnmarshalJSON(data []byte) error {
+	type message Message
+	return unmarshalExtra(data, m, (*message)(m))
+}
@@ -0,0 +1,225 @@
+package reddit
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/google/go-querystring/query"
+)
+
+// FlairType distinguishes user flair templates from link flair templates.
+type FlairType string
+
+// UserFlair and LinkFlair are the supported flair template types.
+const (
+	UserFlair FlairType = "USER_FLAIR"
+	LinkFlair FlairType = "LINK_FLAIR"
+)
+
+// FlairTemplate is a user or link flair template of a subreddit. The url tags are used when creating
+// or updating a template with Config.SaveFlairTemplate.
+type FlairTemplate struct {
+	ID               string        `json:"id" url:"flair_template_id,omitempty"`
+	AllowableContent string        `json:"allowable_content" url:"allowable_content,omitempty"` // all, emoji or text
+	BackgroundColor  string        `json:"background_color" url:"background_color,omitempty"`
+	CSSClass         string        `json:"css_class" url:"css_class,omitempty"`
+	MaxEmojis        int           `json:"max_emojis" url:"max_emojis,omitempty"`
+	ModOnly          bool          `json:"mod_only" url:"mod_only"`
+	Richtext         FlairRichtext `json:"richtext" url:"-"`
+	Text             string        `json:"text" url:"text"`
+	TextColor        string        `json:"text_color" url:"text_color,omitempty"` // dark or light
+	TextEditable     bool          `json:"text_editable" url:"text_editable"`
+	Type             string        `json:"type" url:"-"` // text or richtext
+}
+
+// FlairChoice is a flair that may be selected for a user or link.
+type FlairChoice struct {
+	CSSClass     string `json:"flair_css_class"`
+	Position     string `json:"flair_position"`
+	TemplateID   string `json:"flair_template_id"`
+	Text         string `json:"flair_text"`
+	TextEditable bool   `json:"flair_text_editable"`
+}
+
+// FlairChoices holds the current flair of a user or link and the flairs that may be selected for it.
+type FlairChoices struct {
+	Current FlairChoice   `json:"current"`
+	Choices []FlairChoice `json:"choices"`
+}
+
+// FlairAssignment assigns flair to a user or link. Exactly one of Link and User must be set.
+type FlairAssignment struct {
+	Link            Fullname `url:"link,omitempty"` // Fullname of the Link to assign flair to.
+	User            string   `url:"name,omitempty"` // Name of the user to assign flair to.
+	TemplateID      string   `url:"flair_template_id,omitempty"`
+	Text            string   `url:"text,omitempty"`
+	CSSClass        string   `url:"css_class,omitempty"`
+	BackgroundColor string   `url:"background_color,omitempty"`
+	TextColor       string   `url:"text_color,omitempty"`
+}
+
+func (f FlairAssignment) form() (url.Values, error) {
+	if (f.Link == "") == (f.User == "") {
+		return nil, fmt.Errorf("exactly one of link and user must be set for flair assignment")
+	}
+	if f.Link != "" {
+		if err := f.Link.expect(KindLink); err != nil {
+			return nil, err
+		}
+	}
+	v, err := query.Values(f)
+	if err != nil {
+		return nil, err
+	}
+	v.Set("api_type", "json")
+	return v, nil
+}
+
+func subredditAPIURL(subreddit, endpoint string) string {
+	return fmt.Sprintf("%s/r/%s/api/%s", RedditAPIURL, subreddit, endpoint)
+}
+
+// FlairSelector returns the current flair and the available flair choices for the Link with the
+// provided fullname or, if link is empty, for user.
+func (c *Config) FlairSelector(client *http.Client, subreddit string, link Fullname, user string) (*FlairChoices, error) {
+	form := url.Values{}
+	if link != "" {
+		if err := link.expect(KindLink); err != nil {
+			return nil, err
+		}
+		form.Set("link", string(link))
+	} else {
+		form.Set("name", user)
+	}
+	var choices FlairChoices
+	if err := c.Post(client, subredditAPIURL(subreddit, "flairselector"), form, &choices); err != nil {
+		return nil, err
+	}
+	return &choices, nil
+}
+
+// LinkFlairTemplates returns the link flair templates of subreddit.
+func (c *Config) LinkFlairTemplates(client *http.Client, subreddit string) ([]FlairTemplate, error) {
+	var templates []FlairTemplate
+	if err := c.Get(client, subredditAPIURL(subreddit, "link_flair_v2"), &templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// UserFlairTemplates returns the user flair templates of subreddit.
+func (c *Config) UserFlairTemplates(client *http.Client, subreddit string) ([]FlairTemplate, error) {
+	var templates []FlairTemplate
+	if err := c.Get(client, subredditAPIURL(subreddit, "user_flair_v2"), &templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// SelectFlair assigns flair from a template to a user or link. The text and colors of the template may be
+// overridden if the template allows it.
+func (c *Config) SelectFlair(client *http.Client, subreddit string, f FlairAssignment) error {
+	if f.TemplateID == "" {
+		return fmt.Errorf("no flair template id present")
+	}
+	form, err := f.form()
+	if err != nil {
+		return err
+	}
+	return c.Post(client, subredditAPIURL(subreddit, "selectflair"), form, nil)
+}
+
+// SetFlair assigns free form flair text and CSS class to a user or link. f.TemplateID and colors are ignored.
+func (c *Config) SetFlair(client *http.Client, subreddit string, f FlairAssignment) error {
+	f.TemplateID, f.BackgroundColor, f.TextColor = "", "", ""
+	form, err := f.form()
+	if err != nil {
+		return err
+	}
+	return c.Post(client, subredditAPIURL(subreddit, "flair"), form, nil)
+}
+
+// DeleteFlair removes the flair of user.
+func (c *Config) DeleteFlair(client *http.Client, subreddit, user string) error {
+	form := url.Values{"api_type": {"json"}, "name": {user}}
+	return c.Post(client, subredditAPIURL(subreddit, "deleteflair"), form, nil)
+}
+
+// FlairCSVRow is a single row of a bulk user flair update.
+type FlairCSVRow struct {
+	User     string
+	Text     string
+	CSSClass string
+}
+
+// FlairCSVResult is the result of applying a single FlairCSVRow.
+type FlairCSVResult struct {
+	OK       bool              `json:"ok"`
+	Status   string            `json:"status"`
+	Errors   map[string]string `json:"errors"`
+	Warnings map[string]string `json:"warnings"`
+}
+
+// FlairCSVBatchSize is the maximum number of rows reddit accepts in a single bulk flair update.
+const FlairCSVBatchSize = 100
+
+// SetFlairCSV updates the flair of many users at once. Rows are sent in batches of FlairCSVBatchSize.
+// A row with empty Text and CSSClass removes the flair of the user. The results are returned in the same
+// order as rows. If a batch fails, the results of all previous batches are returned along with the error.
+func (c *Config) SetFlairCSV(client *http.Client, subreddit string, rows []FlairCSVRow) ([]FlairCSVResult, error) {
+	var results []FlairCSVResult
+	for start := 0; start < len(rows); start += FlairCSVBatchSize {
+		end := start + FlairCSVBatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		for _, r := range rows[start:end] {
+			if err := w.Write([]string{r.User, r.Text, r.CSSClass}); err != nil {
+				return results, err
+			}
+		}
+		if w.Flush(); w.Error() != nil {
+			return results, w.Error()
+		}
+		var batch []FlairCSVResult
+		form := url.Values{"flair_csv": {buf.String()}}
+		if err := c.Post(client, subredditAPIURL(subreddit, "flaircsv"), form, &batch); err != nil {
+			return results, err
+		}
+		results = append(results, batch...)
+	}
+	return results, nil
+}
+
+// SaveFlairTemplate creates a flair template of the given type, or updates an existing one if t.ID is set.
+// It returns the saved template.
+func (c *Config) SaveFlairTemplate(client *http.Client, subreddit string, typ FlairType, t FlairTemplate) (*FlairTemplate, error) {
+	form, err := query.Values(t)
+	if err != nil {
+		return nil, err
+	}
+	form.Set("api_type", "json")
+	form.Set("flair_type", string(typ))
+	var saved FlairTemplate
+	if err := c.Post(client, subredditAPIURL(subreddit, "flairtemplate_v2"), form, &saved); err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+// DeleteFlairTemplate deletes the flair template with the provided ID.
+func (c *Config) DeleteFlairTemplate(client *http.Client, subreddit, id string) error {
+	form := url.Values{"api_type": {"json"}, "flair_template_id": {id}}
+	return c.Post(client, subredditAPIURL(subreddit, "deleteflairtemplate"), form, nil)
+}
+
+// ClearFlairTemplates deletes all flair templates of the given type.
+func (c *Config) ClearFlairTemplates(client *http.Client, subreddit string, typ FlairType) error {
+	form := url.Values{"api_type": {"json"}, "flair_type": {string(typ)}}
+	return c.Post(client, subredditAPIURL(subreddit, "clearflairtemplates"), form, nil)
+}
@@ -0,0 +1,70 @@
+package reddit
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_FlairTemplates(t *testing.T) {
+	m := mock(
+		get("https://oauth.reddit.com/r/golang/api/link_flair_v2", `[{
+			"type": "richtext", "text_editable": false, "allowable_content": "all", "text": "Help",
+			"max_emojis": 10, "text_color": "dark", "mod_only": false, "css_class": "help",
+			"richtext": [{"e": "text", "t": "Help"}], "background_color": "#ff0000", "id": "tmpl-1"
+		}]`),
+		post("https://oauth.reddit.com/r/golang/api/flairtemplate_v2",
+			"api_type=json&css_class=news&flair_type=LINK_FLAIR&mod_only=true&text=News&text_editable=false",
+			`{"id": "tmpl-2", "text": "News", "css_class": "news", "mod_only": true, "type": "text"}`),
+		post("https://oauth.reddit.com/r/golang/api/selectflair",
+			"api_type=json&flair_template_id=tmpl-2&link=t3_abc", `{"json": {"errors": []}}`),
+		post("https://oauth.reddit.com/r/golang/api/flair",
+			"api_type=json&css_class=gopher&name=user&text=Gopher", `{"json": {"errors": []}}`),
+	)
+	defer m.reset()
+
+	require := require.New(t)
+	c := authedConfig(m)
+
+	templates, err := c.LinkFlairTemplates(nil, "golang")
+	require.NoError(err)
+	require.Len(templates, 1)
+	require.Equal("tmpl-1", templates[0].ID)
+	require.Equal("#ff0000", templates[0].BackgroundColor)
+	require.Equal(10, templates[0].MaxEmojis)
+
+	saved, err := c.SaveFlairTemplate(nil, "golang", LinkFlair, FlairTemplate{Text: "News", CSSClass: "news", ModOnly: true})
+	require.NoError(err)
+	require.Equal("tmpl-2", saved.ID)
+
+	require.NoError(c.SelectFlair(nil, "golang", FlairAssignment{Link: "t3_abc", TemplateID: "tmpl-2"}))
+	require.NoError(c.SetFlair(nil, "golang", FlairAssignment{User: "user", Text: "Gopher", CSSClass: "gopher"}))
+	require.Error(c.SetFlair(nil, "golang", FlairAssignment{Text: "nobody"}))
+}
+
+func TestConfig_SetFlairCSV(t *testing.T) {
+	rows := make([]FlairCSVRow, FlairCSVBatchSize+1)
+	lines := make([]string, len(rows))
+	for i := range rows {
+		rows[i] = FlairCSVRow{User: fmt.Sprintf("user%d", i), Text: "Gopher, Esq.", CSSClass: "gopher"}
+		lines[i] = fmt.Sprintf("user%d,\"Gopher, Esq.\",gopher\n", i)
+	}
+	result := func(n int) string {
+		return "[" + strings.TrimSuffix(strings.Repeat(`{"ok": true, "status": "added flair", "errors": {}, "warnings": {}},`, n), ",") + "]"
+	}
+	body := func(l []string) string { return url.Values{"flair_csv": {strings.Join(l, "")}}.Encode() }
+
+	m := mock(
+		post("https://oauth.reddit.com/r/golang/api/flaircsv", body(lines[:FlairCSVBatchSize]), result(FlairCSVBatchSize)),
+		post("https://oauth.reddit.com/r/golang/api/flaircsv", body(lines[FlairCSVBatchSize:]), result(1)),
+	)
+	defer m.reset()
+
+	results, err := authedConfig(m).SetFlairCSV(nil, "golang", rows)
+	require.NoError(t, err)
+	require.Len(t, results, len(rows))
+	require.True(t, results[len(rows)-1].OK)
+}
@@ -0,0 +1,177 @@
+package reddit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kind is the type prefix of a Fullname.
+//
+// See https://www.reddit.com/dev/api#fullnames
+type Kind string
+
+// Kinds of Things that can be identified by a Fullname.
+const (
+	KindComment   Kind = "t1"
+	KindAccount   Kind = "t2"
+	KindLink      Kind = "t3"
+	KindMessage   Kind = "t4"
+	KindSubReddit Kind = "t5"
+	KindAward     Kind = "t6"
+)
+
+var kindNames = map[Kind]string{
+	KindComment:   "comment",
+	KindAccount:   "account",
+	KindLink:      "link",
+	KindMessage:   "message",
+	KindSubReddit: "subreddit",
+	KindAward:     "award",
+}
+
+// name returns a human readable name for k, for example "link" for KindLink.
+func (k Kind) name() string {
+	if name, ok := kindNames[k]; ok {
+		return name
+	}
+	return string(k)
+}
+
+// Fullname uniquely identifies a Thing on reddit. It consists of the Kind of the Thing and its base 36 ID
+// joined by an underscore, for example t3_15bfi0.
+//
+// See https://www.reddit.com/dev/api#fullnames
+type Fullname string
+
+// NewFullname returns the Fullname of the Thing of the provided kind with the numeric ID id.
+func NewFullname(kind Kind, id uint64) Fullname {
+	return Fullname(string(kind) + "_" + strconv.FormatUint(id, 36))
+}
+
+// ParseFullname parses and validates a fullname.
+func ParseFullname(s string) (Fullname, error) {
+	f := Fullname(s)
+	if err := f.Validate(); err != nil {
+		return "", err
+	}
+	return f, nil
+}
+
+// Validate returns an error if f is not a well formed fullname of a known kind.
+func (f Fullname) Validate() error {
+	i := strings.IndexByte(string(f), '_')
+	if i < 0 {
+		return fmt.Errorf("invalid fullname %q: no kind present", string(f))
+	}
+	if _, ok := kindNames[Kind(f[:i])]; !ok {
+		return fmt.Errorf("invalid fullname %q: unknown kind %s", string(f), string(f[:i]))
+	}
+	id := string(f[i+1:])
+	if id == "" || strings.ToLower(id) != id {
+		return fmt.Errorf("invalid fullname %q: invalid id %q", string(f), id)
+	}
+	if _, err := strconv.ParseUint(id, 36, 64); err != nil {
+		return fmt.Errorf("invalid fullname %q: invalid id %q", string(f), id)
+	}
+	return nil
+}
+
+// Kind returns the kind of f. It returns an empty Kind if f has no kind.
+func (f Fullname) Kind() Kind {
+	if i := strings.IndexByte(string(f), '_'); i >= 0 {
+		return Kind(f[:i])
+	}
+	return ""
+}
+
+// ID returns the base 36 ID of f, without the kind prefix.
+func (f Fullname) ID() string {
+	return string(f[strings.IndexByte(string(f), '_')+1:])
+}
+
+// Int returns the numeric value of the ID of f. IDs of the same kind are assigned in increasing order, so
+// this can be used to order Things of the same kind by age.
+func (f Fullname) Int() (uint64, error) {
+	if err := f.Validate(); err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(f.ID(), 36, 64)
+}
+
+// Is returns true iff f is a valid fullname of one of the provided kinds.
+func (f Fullname) Is(kinds ...Kind) bool {
+	if f.Validate() != nil {
+		return false
+	}
+	for _, k := range kinds {
+		if f.Kind() == k {
+			return true
+		}
+	}
+	return false
+}
+
+// expect returns an error if f is not a valid fullname of one of the provided kinds.
+func (f Fullname) expect(kinds ...Kind) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	if f.Is(kinds...) {
+		return nil
+	}
+	names := make([]string, len(kinds))
+	for i, k := range kinds {
+		names[i] = k.name()
+	}
+	return fmt.Errorf("%s is a %s, expected a %s", string(f), f.Kind().name(), strings.Join(names, " or "))
+}
+
+// Less returns true iff f orders before g. Fullnames are ordered by kind and then by numeric ID.
+// Invalid fullnames order before valid ones.
+func (f Fullname) Less(g Fullname) bool {
+	fi, ferr := f.Int()
+	gi, gerr := g.Int()
+	switch {
+	case ferr != nil || gerr != nil:
+		return ferr != nil && gerr == nil
+	case f.Kind() != g.Kind():
+		return f.Kind() < g.Kind()
+	default:
+		return fi < gi
+	}
+}
+
+// MaxFullnameRange is the largest number of fullnames FullnameRange returns.
+const MaxFullnameRange = 10000
+
+// FullnameRange returns all fullnames of the kind of from with IDs between those of from and to, inclusive.
+// from and to must be of the same kind, from must not be after to and the range may hold at most
+// MaxFullnameRange fullnames.
+func FullnameRange(from, to Fullname) ([]Fullname, error) {
+	start, err := from.Int()
+	if err != nil {
+		return nil, err
+	}
+	end, err := to.Int()
+	if err != nil {
+		return nil, err
+	}
+	if from.Kind() != to.Kind() {
+		return nil, fmt.Errorf("fullnames %s and %s are of different kinds", string(from), string(to))
+	}
+	if start > end {
+		return nil, fmt.Errorf("fullname %s is after %s", string(from), string(to))
+	}
+	if end-start >= MaxFullnameRange {
+		return nil, fmt.Errorf("range from %s to %s holds %d fullnames, more than the maximum of %d", string(from), string(to), end-start+1, MaxFullnameRange)
+	}
+	names := make([]Fullname, 0, end-start+1)
+	for i := start; i <= end; i++ {
+		names = append(names, NewFullname(from.Kind(), i))
+		if i == end {
+			break
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,58 @@
+package reddit
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFullname(t *testing.T) {
+	require := require.New(t)
+
+	f, err := ParseFullname("t3_15bfi0")
+	require.NoError(err)
+	require.Equal(KindLink, f.Kind())
+	require.Equal("15bfi0", f.ID())
+	id, err := f.Int()
+	require.NoError(err)
+	require.Equal(uint64(69397560), id)
+	require.Equal(f, NewFullname(KindLink, id))
+	require.True(f.Is(KindComment, KindLink))
+	require.False(f.Is(KindComment))
+
+	for _, invalid := range []string{"", "15bfi0", "t9_abc", "t3_", "t3_ABC", "t3_a-b", "t3_zzzzzzzzzzzzzzzzzzzz"} {
+		_, err := ParseFullname(invalid)
+		require.Error(err, invalid)
+	}
+}
+
+func TestFullname_Ordering(t *testing.T) {
+	names := []Fullname{"t3_10", "t1_zz", "t3_z", "t3_9", "invalid"}
+	sort.Slice(names, func(i, j int) bool { return names[i].Less(names[j]) })
+	require.Equal(t, []Fullname{"invalid", "t1_zz", "t3_9", "t3_z", "t3_10"}, names)
+
+	r, err := FullnameRange("t1_y", "t1_11")
+	require.NoError(t, err)
+	require.Equal(t, []Fullname{"t1_y", "t1_z", "t1_10", "t1_11"}, r)
+
+	_, err = FullnameRange("t1_y", "t3_11")
+	require.Error(t, err)
+	_, err = FullnameRange("t1_11", "t1_y")
+	require.EqualError(t, err, "fullname t1_11 is after t1_y")
+	_, err = FullnameRange("t3_1", "t3_zzzzzz")
+	require.EqualError(t, err, "range from t3_1 to t3_zzzzzz holds 2176782335 fullnames, more than the maximum of 10000")
+	r, err = FullnameRange("t3_1", NewFullname(KindLink, MaxFullnameRange))
+	require.NoError(t, err)
+	require.Len(t, r, MaxFullnameRange)
+}
+
+func TestConfig_ModerationWrongKind(t *testing.T) {
+	m := mock()
+	defer m.reset()
+
+	c := authedConfig(m)
+	require.EqualError(t, c.MarkNSFW(nil, "t1_abc"), "t1_abc is a comment, expected a link")
+	require.EqualError(t, c.Approve(nil, "t5_abc"), "t5_abc is a subreddit, expected a link or comment")
+	require.Error(t, c.Lock(nil, "abc"))
+}
@@ -0,0 +1,111 @@
+package reddit
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// InfoConcurrency is the maximum number of requests Config.Info performs concurrently.
+const InfoConcurrency = 4
+
+// InfoResult holds the result of Config.Info.
+type InfoResult struct {
+	Things  []Thing    // Things returned by reddit, in the order their fullnames were requested.
+	Missing []Fullname // Requested fullnames that reddit did not return, in the order they were requested.
+}
+
+// listing fetches a single Listing from url.
+func (c *Config) listing(client *http.Client, url string) (*Listing, error) {
+	var t Thing
+	if err := c.Get(client, url, &t); err != nil {
+		return nil, err
+	}
+	l, ok := t.Data.(*Listing)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %s response from %s", t.Kind, url)
+	}
+	return l, nil
+}
+
+// Info fetches the Links, Comments and SubReddits with the provided fullnames. Fullnames are requested in
+// batches of 100, with up to InfoConcurrency batches in flight at once. All requests respect the rate limit
+// reported by reddit.
+//
+// Only fullnames of Links, Comments and SubReddits are accepted. Fullnames of items reddit did not return,
+// such as those of deleted items, are reported in InfoResult.Missing.
+func (c *Config) Info(client *http.Client, fullnames ...Fullname) (*InfoResult, error) {
+	var batches [][]string
+	for start := 0; start < len(fullnames); start += infoBatchSize {
+		end := start + infoBatchSize
+		if end > len(fullnames) {
+			end = len(fullnames)
+		}
+		batch := make([]string, 0, end-start)
+		for _, f := range fullnames[start:end] {
+			if err := f.expect(KindComment, KindLink, KindSubReddit); err != nil {
+				return nil, err
+			}
+			batch = append(batch, string(f))
+		}
+		batches = append(batches, batch)
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		found    = make(map[Fullname]Thing, len(fullnames))
+		sem      = make(chan struct{}, InfoConcurrency)
+	)
+	for _, batch := range batches {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(batch []string) {
+			defer func() { <-sem; wg.Done() }()
+			l, err := c.listing(client, fmt.Sprintf("%s/api/info.json?id=%s", RedditAPIURL, url.QueryEscape(strings.Join(batch, ","))))
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			for _, t := range l.Children {
+				found[t.Name] = t
+			}
+		}(batch)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	result := &InfoResult{}
+	for _, name := range fullnames {
+		if t, ok := found[name]; ok {
+			result.Things = append(result.Things, t)
+		} else {
+			result.Missing = append(result.Missing, name)
+		}
+	}
+	return result, nil
+}
+
+// InfoByURL fetches the Links that were submitted with the provided URL.
+func (c *Config) InfoByURL(client *http.Client, link string) ([]Thing, error) {
+	l, err := c.listing(client, fmt.Sprintf("%s/api/info.json?url=%s", RedditAPIURL, url.QueryEscape(link)))
+	if err != nil {
+		return nil, err
+	}
+	return l.Children, nil
+}
@@ -0,0 +1,86 @@
+package reddit
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func infoBody(fullnames []Fullname) string {
+	children := make([]string, len(fullnames))
+	for i, name := range fullnames {
+		kind, id := name.Kind(), name.ID()
+		children[i] = fmt.Sprintf(`{"kind": "%s", "data": {"id": "%s", "name": "%s", "score": %d}}`, kind, id, name, i)
+	}
+	return fmt.Sprintf(`{"kind": "Listing", "data": {"children": [%s]}}`, strings.Join(children, ","))
+}
+
+func TestConfig_Info(t *testing.T) {
+	var fullnames []Fullname
+	for i := 0; i < 250; i++ {
+		kind := KindLink
+		if i%2 == 1 {
+			kind = KindComment
+		}
+		fullnames = append(fullnames, Fullname(fmt.Sprintf("%s_%d", kind, i)))
+	}
+	missing := map[Fullname]bool{"t3_10": true, "t1_101": true, "t3_248": true}
+
+	var responses []response
+	for start := 0; start < len(fullnames); start += 100 {
+		end := start + 100
+		if end > len(fullnames) {
+			end = len(fullnames)
+		}
+		var returned []Fullname
+		for _, name := range fullnames[start:end] {
+			if !missing[name] {
+				returned = append(returned, name)
+			}
+		}
+		// reddit returns items in its own order
+		for i, j := 0, len(returned)-1; i < j; i, j = i+1, j-1 {
+			returned[i], returned[j] = returned[j], returned[i]
+		}
+		ids := make([]string, end-start)
+		for i, name := range fullnames[start:end] {
+			ids[i] = string(name)
+		}
+		u := "https://oauth.reddit.com/api/info.json?id=" + url.QueryEscape(strings.Join(ids, ","))
+		responses = append(responses, get(u, infoBody(returned)))
+	}
+	m := mockUnordered(responses...)
+	defer m.reset()
+
+	require := require.New(t)
+	result, err := authedConfig(m).Info(nil, fullnames...)
+	require.NoError(err)
+	require.Equal([]Fullname{"t3_10", "t1_101", "t3_248"}, result.Missing)
+	require.Len(result.Things, len(fullnames)-len(missing))
+
+	var names []Fullname
+	for _, name := range fullnames {
+		if !missing[name] {
+			names = append(names, name)
+		}
+	}
+	for i, thing := range result.Things {
+		require.Equal(names[i], thing.Name)
+	}
+	require.IsType(&Comment{}, result.Things[1].Data)
+	require.Equal(Fullname("t1_1"), result.Things[1].Data.(*Comment).Name)
+}
+
+func TestConfig_InfoByURL(t *testing.T) {
+	m := mock(get("https://oauth.reddit.com/api/info.json?url=https%3A%2F%2Fgolang.org%2F", infoBody([]Fullname{"t3_abc", "t3_def"})))
+	defer m.reset()
+
+	things, err := authedConfig(m).InfoByURL(nil, "https://golang.org/")
+	require.NoError(t, err)
+	require.Len(t, things, 2)
+	require.Equal(t, Fullname("t3_def"), things[1].Name)
+	require.Equal(t, "def", things[1].ID)
+}
@@ -0,0 +1,143 @@
+// Package instrumentation records Prometheus metrics and OpenTelemetry traces for the requests made by
+// the reddit package. Both are reddit.Middleware, added to a Config with reddit.WithMiddleware:
+//
+//	metrics, err := instrumentation.NewMetrics(prometheus.DefaultRegisterer)
+//	if err != nil {
+//		...
+//	}
+//	cfg.Configure(
+//		reddit.WithMiddleware(metrics.Middleware()),
+//		reddit.WithMiddleware(instrumentation.Tracing(otel.GetTracerProvider())),
+//	)
+//
+// Requests are labelled by endpoint, the path of the request with names and IDs replaced by placeholders as
+// returned by reddit.RequestInfo.Endpoint, so that the number of series stays small.
+package instrumentation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	reddit "github.com/sridharv/reddit-go"
+)
+
+// Metrics holds the Prometheus collectors updated by its Middleware. A Metrics can be shared by many
+// Configs. The rate limit gauges hold the state reported by the latest response, so Configs that use
+// different accounts should use Metrics registered with different constant labels, see
+// prometheus.WrapRegistererWith.
+type Metrics struct {
+	requests  *prometheus.CounterVec
+	latency   *prometheus.HistogramVec
+	errors    *prometheus.CounterVec
+	remaining prometheus.Gauge
+	used      prometheus.Gauge
+	reset     prometheus.Gauge
+	tokens    prometheus.Counter
+	pages     prometheus.Counter
+	items     prometheus.Counter
+}
+
+// NewMetrics creates Metrics and registers their collectors with reg. The collectors are:
+//
+//	reddit_requests_total{method, endpoint, code}          Responses received, including rate limited ones.
+//	reddit_request_duration_seconds{method, endpoint}      Time taken to send requests and read responses.
+//	reddit_request_errors_total{method, endpoint}          Requests that failed, including those whose response
+//	                                                       reported errors or could not be decoded.
+//	reddit_ratelimit_remaining                             Requests remaining in the current rate limit period.
+//	reddit_ratelimit_used                                  Requests used in the current rate limit period.
+//	reddit_ratelimit_reset_timestamp_seconds               Unix time at which the rate limit period ends.
+//	reddit_token_refreshes_total                           OAuth tokens obtained. Failed logins are not counted.
+//	reddit_stream_pages_total                              Listing pages fetched by Streams.
+//	reddit_stream_items_total                              Things in the Listing pages fetched by Streams.
+func NewMetrics(reg prometheus.Registerer) (*Metrics, error) {
+	endpoint := []string{"method", "endpoint"}
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "reddit", Name: "requests_total", Help: "Responses received from reddit, including rate limited ones.",
+		}, append(endpoint, "code")),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "reddit", Name: "request_duration_seconds", Help: "Time taken to send requests to reddit and read the responses.",
+		}, endpoint),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "reddit", Name: "request_errors_total", Help: "Requests to reddit that failed.",
+		}, endpoint),
+		remaining: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "reddit", Name: "ratelimit_remaining", Help: "Requests remaining in the current rate limit period.",
+		}),
+		used: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "reddit", Name: "ratelimit_used", Help: "Requests used in the current rate limit period.",
+		}),
+		reset: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "reddit", Name: "ratelimit_reset_timestamp_seconds", Help: "Unix time at which the current rate limit period ends.",
+		}),
+		tokens: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "reddit", Name: "token_refreshes_total", Help: "OAuth tokens obtained from reddit.",
+		}),
+		pages: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "reddit", Name: "stream_pages_total", Help: "Listing pages fetched by Streams.",
+		}),
+		items: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "reddit", Name: "stream_items_total", Help: "Things in the Listing pages fetched by Streams.",
+		}),
+	}
+	for _, c := range []prometheus.Collector{m.requests, m.latency, m.errors, m.remaining, m.used, m.reset, m.tokens, m.pages, m.items} {
+		if err := reg.Register(c); err != nil {
+			return nil, fmt.Errorf("failed to register reddit metrics: %v", err)
+		}
+	}
+	return m, nil
+}
+
+// Middleware returns reddit.Middleware that updates m.
+func (m *Metrics) Middleware() reddit.Middleware {
+	return reddit.Middleware{
+		AfterResponse: func(info *reddit.RequestInfo) {
+			method, endpoint := info.Request.Method, info.Endpoint()
+			m.requests.WithLabelValues(method, endpoint, strconv.Itoa(info.Response.StatusCode)).Inc()
+			m.latency.WithLabelValues(method, endpoint).Observe(info.Duration.Seconds())
+			if info.HasRateLimit {
+				m.remaining.Set(info.RateLimit.Remaining)
+				m.used.Set(float64(info.RateLimit.Used))
+				m.reset.Set(float64(info.RateLimit.Reset.Unix()))
+			}
+			if info.Err != nil || info.Response.StatusCode != http.StatusOK {
+				return
+			}
+			if info.Token && hasToken(info.Body) {
+				m.tokens.Inc()
+			}
+			if info.StreamPage > 0 {
+				m.pages.Inc()
+				m.items.Add(float64(listingSize(info.Body)))
+			}
+		},
+		OnError: func(info *reddit.RequestInfo, err error) {
+			m.errors.WithLabelValues(info.Request.Method, info.Endpoint()).Inc()
+		},
+	}
+}
+
+// listingSize returns the number of children of the Listing in body.
+func listingSize(body []byte) int {
+	var l struct {
+		Data struct {
+			Children []json.RawMessage `json:"children"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &l); err != nil {
+		return 0
+	}
+	return len(l.Data.Children)
+}
+
+// hasToken returns true if body is a token response holding an access token. Reddit reports invalid
+// credentials in a 200 OK response without one.
+func hasToken(body []byte) bool {
+	var t struct {
+		Token string `json:"access_token"`
+	}
+	return json.Unmarshal(body, &t) == nil && t.Token != ""
+}
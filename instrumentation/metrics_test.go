@@ -0,0 +1,88 @@
+package instrumentation
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	reddit "github.com/sridharv/reddit-go"
+	"github.com/sridharv/reddit-go/reddittest"
+	"github.com/stretchr/testify/require"
+)
+
+func links(n int) []reddit.Thing {
+	things := make([]reddit.Thing, n)
+	for i := range things {
+		id := string(rune('a' + i))
+		things[i] = reddittest.Link(id, "Link "+id)
+	}
+	return things
+}
+
+func TestMetrics(t *testing.T) {
+	require := require.New(t)
+	s := reddittest.NewServer()
+	defer s.Close()
+	s.AddListing("/r/golang/new", links(5)...)
+	s.Fail(reddittest.Failure{Path: "/r/golang/about.json", StatusCode: http.StatusInternalServerError, Body: "oops"})
+
+	reg := prometheus.NewPedanticRegistry()
+	m, err := NewMetrics(reg)
+	require.NoError(err)
+	_, err = NewMetrics(reg)
+	require.Error(err)
+
+	cfg := s.Config().Configure(reddit.WithMiddleware(m.Middleware()))
+	require.NoError(cfg.AuthScript(s.Client()))
+	stream := cfg.Stream(s.Client(), &reddit.SubRedditPosts{SubReddit: "golang", Sort: reddit.LinksNew, ListingOptions: reddit.ListingOptions{Limit: 2}})
+	for stream.Next() {
+	}
+	require.NoError(stream.Error())
+	_, err = cfg.SubReddit(s.Client(), "golang")
+	require.Error(err)
+
+	require.Equal(1.0, testutil.ToFloat64(m.requests.WithLabelValues("POST", "/api/v1/access_token", "200")))
+	require.Equal(3.0, testutil.ToFloat64(m.requests.WithLabelValues("GET", "/r/{subreddit}/new.json", "200")))
+	require.Equal(1.0, testutil.ToFloat64(m.requests.WithLabelValues("GET", "/r/{subreddit}/about.json", "500")))
+	require.Equal(3, testutil.CollectAndCount(m.latency))
+	require.Equal(1, testutil.CollectAndCount(m.errors))
+	require.Equal(1.0, testutil.ToFloat64(m.errors.WithLabelValues("GET", "/r/{subreddit}/about.json")))
+	require.Equal(1.0, testutil.ToFloat64(m.tokens))
+	require.Equal(3.0, testutil.ToFloat64(m.pages))
+	require.Equal(5.0, testutil.ToFloat64(m.items))
+	require.Equal(596.0, testutil.ToFloat64(m.remaining))
+	require.Equal(4.0, testutil.ToFloat64(m.used))
+	require.NotZero(testutil.ToFloat64(m.reset))
+
+	count, err := testutil.GatherAndCount(reg)
+	require.NoError(err)
+	require.Equal(3+3+1+6, count)
+}
+
+func TestMetrics_ResponseErrors(t *testing.T) {
+	require := require.New(t)
+	s := reddittest.NewServer()
+	defer s.Close()
+	s.Fail(reddittest.Failure{Path: "/r/golang/new.json", StatusCode: http.StatusOK, Body: "<html>"})
+
+	m, err := NewMetrics(prometheus.NewPedanticRegistry())
+	require.NoError(err)
+
+	// Reddit reports a wrong password with invalid_grant in a 200 OK response.
+	bad := s.Config().Configure(reddit.WithMiddleware(m.Middleware()))
+	bad.Credentials.Password = "wrong"
+	require.Error(bad.AuthScript(s.Client()))
+	require.Equal(0.0, testutil.ToFloat64(m.tokens))
+	require.Equal(1.0, testutil.ToFloat64(m.errors.WithLabelValues("POST", "/api/v1/access_token")))
+
+	cfg := s.Config().Configure(reddit.WithMiddleware(m.Middleware()))
+	require.NoError(cfg.AuthScript(s.Client()))
+	require.Equal(1.0, testutil.ToFloat64(m.tokens))
+	stream := cfg.Stream(s.Client(), &reddit.SubRedditPosts{SubReddit: "golang", Sort: reddit.LinksNew})
+	require.False(stream.Next())
+	require.Error(stream.Error())
+	require.Equal(1.0, testutil.ToFloat64(m.requests.WithLabelValues("GET", "/r/{subreddit}/new.json", "200")))
+	require.Equal(1.0, testutil.ToFloat64(m.errors.WithLabelValues("GET", "/r/{subreddit}/new.json")))
+	require.Equal(0.0, testutil.ToFloat64(m.pages))
+}
@@ -0,0 +1,113 @@
+package instrumentation
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	reddit "github.com/sridharv/reddit-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the Tracer that creates the spans of Tracing.
+const TracerName = "github.com/sridharv/reddit-go/instrumentation"
+
+type spansKey struct{}
+
+// spans holds the state of a request that is kept across attempts, in the context of its http.Request.
+type spans struct {
+	parent context.Context // Context in which attempts are started. It holds page if set.
+	page   trace.Span      // Span of the Stream page fetched by the request, if any.
+}
+
+// Tracing returns reddit.Middleware that creates an OpenTelemetry span with tp for each attempt at an HTTP
+// request. The span is a child of the span in the context of the request, if any. Each Listing page
+// fetched by a Stream gets a span of its own, which is the parent of the spans of the attempts to fetch
+// it and records the number of Things on the page. Error messages are redacted with
+// reddit.RequestInfo.Redact. If tp is nil the global TracerProvider is used.
+func Tracing(tp trace.TracerProvider) reddit.Middleware {
+	tracer := func() trace.Tracer {
+		if tp == nil {
+			return otel.GetTracerProvider().Tracer(TracerName)
+		}
+		return tp.Tracer(TracerName)
+	}
+	return reddit.Middleware{
+		BeforeRequest: func(info *reddit.RequestInfo) error {
+			ctx, endpoint := info.Request.Context(), info.Endpoint()
+			s, ok := ctx.Value(spansKey{}).(*spans)
+			if !ok {
+				s = &spans{parent: ctx}
+				if info.StreamPage > 0 {
+					s.parent, s.page = tracer().Start(ctx, "stream page "+endpoint, trace.WithAttributes(
+						attribute.String("url.template", endpoint),
+						attribute.Int("reddit.stream.page", info.StreamPage),
+					))
+				}
+			}
+			attrs := []attribute.KeyValue{
+				attribute.String("http.request.method", info.Request.Method),
+				attribute.String("url.full", info.Request.URL.String()),
+				attribute.String("url.template", endpoint),
+				attribute.String("server.address", info.Request.URL.Hostname()),
+			}
+			if info.Attempt > 0 {
+				attrs = append(attrs, attribute.Int("http.request.resend_count", info.Attempt))
+			}
+			if info.Token {
+				attrs = append(attrs, attribute.Bool("reddit.token", true))
+			}
+			ctx, _ = tracer().Start(s.parent, info.Request.Method+" "+endpoint,
+				trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+			info.Request = info.Request.WithContext(context.WithValue(ctx, spansKey{}, s))
+			return nil
+		},
+		AfterResponse: func(info *reddit.RequestInfo) {
+			ctx, code := info.Request.Context(), info.Response.StatusCode
+			s, ok := ctx.Value(spansKey{}).(*spans)
+			if !ok {
+				return
+			}
+			span := trace.SpanFromContext(ctx)
+			span.SetAttributes(attribute.Int("http.response.status_code", code))
+			if info.HasRateLimit {
+				span.SetAttributes(attribute.Float64("reddit.ratelimit.remaining", info.RateLimit.Remaining))
+			}
+			switch {
+			case code >= http.StatusBadRequest:
+				span.SetAttributes(attribute.String("error.type", strconv.Itoa(code)))
+				span.SetStatus(codes.Error, http.StatusText(code))
+			case info.Err != nil:
+				// The response reported errors or could not be decoded.
+				span.SetAttributes(attribute.String("error.type", "response"))
+				span.SetStatus(codes.Error, info.Redact(info.Err.Error()))
+			}
+			span.End()
+			if s.page != nil && info.Err == nil && code == http.StatusOK {
+				s.page.SetAttributes(attribute.Int("reddit.stream.items", listingSize(info.Body)))
+				s.page.End()
+			}
+		},
+		OnError: func(info *reddit.RequestInfo, err error) {
+			ctx, msg := info.Request.Context(), info.Redact(err.Error())
+			s, ok := ctx.Value(spansKey{}).(*spans)
+			if !ok {
+				return
+			}
+			if info.Response == nil {
+				// The attempt did not get a response, so AfterResponse has not ended its span.
+				span := trace.SpanFromContext(ctx)
+				span.SetAttributes(attribute.String("error.type", "request"))
+				span.SetStatus(codes.Error, msg)
+				span.End()
+			}
+			if s.page != nil {
+				s.page.SetStatus(codes.Error, msg)
+				s.page.End()
+			}
+		},
+	}
+}
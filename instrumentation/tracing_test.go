@@ -0,0 +1,130 @@
+package instrumentation
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	reddit "github.com/sridharv/reddit-go"
+	"github.com/sridharv/reddit-go/reddittest"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// describe summarises spans with their parents, statuses and the attributes that Tracing sets.
+func describe(spans []sdktrace.ReadOnlySpan) []string {
+	names := map[string]string{}
+	for _, s := range spans {
+		names[s.SpanContext().SpanID().String()] = s.Name()
+	}
+	keys := []string{"http.response.status_code", "http.request.resend_count", "error.type", "reddit.token", "reddit.stream.page", "reddit.stream.items"}
+	var ret []string
+	for _, s := range spans {
+		line := s.Name()
+		if parent, ok := names[s.Parent().SpanID().String()]; ok {
+			line += " parent=" + parent
+		}
+		line += " status=" + s.Status().Code.String()
+		for _, key := range keys {
+			for _, kv := range s.Attributes() {
+				if string(kv.Key) == key {
+					line += fmt.Sprintf(" %s=%s", key, kv.Value.Emit())
+				}
+			}
+		}
+		ret = append(ret, line)
+	}
+	return ret
+}
+
+func attributeValue(s sdktrace.ReadOnlySpan, key string) string {
+	for _, kv := range s.Attributes() {
+		if string(kv.Key) == key {
+			return kv.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestTracing(t *testing.T) {
+	require := require.New(t)
+	s := reddittest.NewServer()
+	defer s.Close()
+	s.AddListing("/r/golang/new", links(5)...)
+	s.Fail(reddittest.Failure{
+		Path:       "/r/golang/new.json",
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"X-Ratelimit-Used": {"600"}, "X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"0"}},
+	})
+	s.Fail(reddittest.Failure{Path: "/r/golang/about.json", StatusCode: http.StatusInternalServerError, Body: "oops"})
+	s.Fail(reddittest.Failure{Path: "/r/go/new.json", StatusCode: http.StatusForbidden, Body: "denied " + s.Token})
+	s.Fail(reddittest.Failure{Path: "/r/gocode/new.json", StatusCode: http.StatusOK, Body: "<html>"})
+
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	cfg := s.Config().Configure(reddit.WithMiddleware(Tracing(tp)))
+	require.NoError(cfg.AuthScript(s.Client()))
+	stream := cfg.Stream(s.Client(), &reddit.SubRedditPosts{SubReddit: "golang", Sort: reddit.LinksNew, ListingOptions: reddit.ListingOptions{Limit: 3}})
+	for stream.Next() {
+	}
+	require.NoError(stream.Error())
+	_, err := cfg.SubReddit(s.Client(), "golang")
+	require.Error(err)
+	stream = cfg.Stream(s.Client(), &reddit.SubRedditPosts{SubReddit: "go", Sort: reddit.LinksNew})
+	require.False(stream.Next())
+	require.Error(stream.Error())
+	stream = cfg.Stream(s.Client(), &reddit.SubRedditPosts{SubReddit: "gocode", Sort: reddit.LinksNew})
+	require.False(stream.Next())
+	require.Error(stream.Error())
+
+	require.Empty(rec.Started()[len(rec.Ended()):])
+	spans := rec.Ended()
+	require.Equal([]string{
+		"POST /api/v1/access_token status=Unset http.response.status_code=200 reddit.token=true",
+		"GET /r/{subreddit}/new.json parent=stream page /r/{subreddit}/new.json status=Error http.response.status_code=429 error.type=429",
+		"GET /r/{subreddit}/new.json parent=stream page /r/{subreddit}/new.json status=Unset http.response.status_code=200 http.request.resend_count=1",
+		"stream page /r/{subreddit}/new.json status=Unset reddit.stream.page=1 reddit.stream.items=3",
+		"GET /r/{subreddit}/new.json parent=stream page /r/{subreddit}/new.json status=Unset http.response.status_code=200",
+		"stream page /r/{subreddit}/new.json status=Unset reddit.stream.page=2 reddit.stream.items=2",
+		"GET /r/{subreddit}/about.json status=Error http.response.status_code=500 error.type=500",
+		"GET /r/{subreddit}/new.json parent=stream page /r/{subreddit}/new.json status=Error http.response.status_code=403 error.type=403",
+		"stream page /r/{subreddit}/new.json status=Error reddit.stream.page=1",
+		"GET /r/{subreddit}/new.json parent=stream page /r/{subreddit}/new.json status=Error http.response.status_code=200 error.type=response",
+		"stream page /r/{subreddit}/new.json status=Error reddit.stream.page=1",
+	}, describe(spans))
+
+	// Attempts at a page share its span as parent, and error messages are redacted.
+	require.Equal(spans[3].SpanContext().SpanID(), spans[1].Parent().SpanID())
+	require.Equal(spans[3].SpanContext().SpanID(), spans[2].Parent().SpanID())
+	require.Equal(spans[5].SpanContext().SpanID(), spans[4].Parent().SpanID())
+	desc := spans[8].Status().Description
+	require.Contains(desc, "http error 403 for "+s.URL+"/r/go/new.json")
+	require.True(strings.HasSuffix(desc, ": denied REDACTED"), desc)
+	require.NotContains(desc, s.Token)
+	require.Contains(spans[10].Status().Description, "failed to parse response from "+s.URL+"/r/gocode/new.json")
+	require.Equal(s.URL+"/r/golang/about.json", attributeValue(spans[6], "url.full"))
+	require.Equal("/r/{subreddit}/about.json", attributeValue(spans[6], "url.template"))
+}
+
+func TestTracing_RequestError(t *testing.T) {
+	require := require.New(t)
+	s := reddittest.NewServer()
+	defer s.Close()
+
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	cfg := s.Config().Configure(reddit.WithMiddleware(Tracing(tp)))
+	require.NoError(cfg.AuthScript(s.Client()))
+	s.Close()
+	stream := cfg.Stream(s.Client(), &reddit.SubRedditPosts{SubReddit: "golang", Sort: reddit.LinksNew})
+	require.False(stream.Next())
+	require.Error(stream.Error())
+
+	require.Equal([]string{
+		"POST /api/v1/access_token status=Unset http.response.status_code=200 reddit.token=true",
+		"GET /r/{subreddit}/new.json parent=stream page /r/{subreddit}/new.json status=Error error.type=request",
+		"stream page /r/{subreddit}/new.json status=Error reddit.stream.page=1",
+	}, describe(rec.Ended()))
+}
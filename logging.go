@@ -0,0 +1,149 @@
+package reddit
+
+import (
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// LogLevels are the levels at which LoggingMiddleware logs requests.
+type LogLevels struct {
+	Success     slog.Level // Requests that got a 200 OK response.
+	RateLimited slog.Level // Responses with status 429 Too Many Requests, which are retried.
+	Failure     slog.Level // Requests that failed.
+}
+
+// DefaultLogLevels are the levels used by WithLogger.
+var DefaultLogLevels = LogLevels{Success: slog.LevelDebug, RateLimited: slog.LevelWarn, Failure: slog.LevelError}
+
+// WithLogger logs every request made by a Config to l, at DefaultLogLevels. If l is nil slog.Default() is used.
+func WithLogger(l *slog.Logger) Option {
+	return WithMiddleware(LoggingMiddleware(l, DefaultLogLevels))
+}
+
+// LoggingMiddleware returns Middleware that logs each request to l with its method, endpoint, status, latency,
+// remaining rate limit, attempt and stream page. The endpoint is given by RequestInfo.Endpoint. Credentials
+// and tokens are never logged.
+// If l is nil slog.Default() is used.
+func LoggingMiddleware(l *slog.Logger, levels LogLevels) Middleware {
+	logger := func() *slog.Logger {
+		if l == nil {
+			return slog.Default()
+		}
+		return l
+	}
+	return Middleware{
+		AfterResponse: func(info *RequestInfo) {
+			switch {
+			case info.Err != nil:
+				// Logged by OnError.
+			case info.Response.StatusCode == http.StatusOK:
+				logRequest(logger(), levels.Success, "reddit request", info, nil)
+			case info.Response.StatusCode == http.StatusTooManyRequests:
+				logRequest(logger(), levels.RateLimited, "reddit request rate limited", info, nil)
+			}
+		},
+		OnError: func(info *RequestInfo, err error) {
+			logRequest(logger(), levels.Failure, "reddit request failed", info, err)
+		},
+	}
+}
+
+func logRequest(l *slog.Logger, level slog.Level, msg string, info *RequestInfo, err error) {
+	ctx := info.Request.Context()
+	if !l.Enabled(ctx, level) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("method", info.Request.Method),
+		slog.String("endpoint", info.Endpoint()),
+	}
+	if info.Response != nil {
+		attrs = append(attrs, slog.Int("status", info.Response.StatusCode), slog.Duration("latency", info.Duration))
+	}
+	if info.HasRateLimit {
+		attrs = append(attrs, slog.Float64("ratelimit_remaining", info.RateLimit.Remaining))
+	}
+	attrs = append(attrs, slog.Int("attempt", info.Attempt))
+	if info.StreamPage > 0 {
+		attrs = append(attrs, slog.Int("page", info.StreamPage))
+	}
+	if info.Token {
+		attrs = append(attrs, slog.Bool("token", true))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", info.Redact(err.Error())))
+	}
+	l.LogAttrs(ctx, level, msg, attrs...)
+}
+
+var (
+	fullnameSegment = regexp.MustCompile(`^t[1-6]_[0-9a-z]+$`)
+	secretPattern   = regexp.MustCompile(`(?i)((?:access_token|refresh_token|password|client_secret)"?\s*[:=]\s*"?)[^&"\s,}]+`)
+	bearerPattern   = regexp.MustCompile(`(?i)(bearer\s+)\S+`)
+)
+
+// wikiActions are the path segments after /wiki/ that are not page names.
+var wikiActions = map[string]bool{
+	"pages": true, "revisions": true, "settings": true, "edit": true, "revert": true, "alloweditor": true,
+	"hide": true, "discussions": true,
+}
+
+// endpointTemplate returns path with subreddit, user, multireddit and wiki page names, IDs and fullnames
+// replaced by placeholders.
+func endpointTemplate(path string) string {
+	ext := ""
+	if i := strings.LastIndexByte(path, '.'); i > strings.LastIndexByte(path, '/') {
+		path, ext = path[:i], path[i:]
+	}
+	orig := strings.Split(strings.Trim(path, "/"), "/")
+	segs := append([]string(nil), orig...)
+	prev := func(i, n int) string {
+		if i >= n {
+			return orig[i-n]
+		}
+		return ""
+	}
+	for i, s := range orig {
+		switch {
+		case prev(i, 1) == "wiki" && wikiActions[s]:
+			if i+1 < len(orig) {
+				segs = append(segs[:i+1], "{page}")
+			}
+			return "/" + strings.Join(segs, "/") + ext
+		case prev(i, 1) == "wiki":
+			segs = append(segs[:i], "{page}")
+			return "/" + strings.Join(segs, "/") + ext
+		case prev(i, 1) == "r":
+			segs[i] = "{subreddit}"
+		case prev(i, 1) == "user" || prev(i, 1) == "u":
+			segs[i] = "{user}"
+		case prev(i, 1) == "m":
+			segs[i] = "{multi}"
+		case prev(i, 1) == "comments" || prev(i, 3) == "comments" || prev(i, 1) == "conversations":
+			segs[i] = "{id}"
+		case prev(i, 2) == "comments":
+			segs[i] = "{slug}"
+		case prev(i, 1) == "emoji":
+			segs[i] = "{name}"
+		case prev(i, 2) == "api" && prev(i, 1) == "v1" && i+1 < len(orig) && strings.HasPrefix(orig[i+1], "emoji"):
+			segs[i] = "{subreddit}"
+		case fullnameSegment.MatchString(s):
+			segs[i] = "{fullname}"
+		}
+	}
+	return "/" + strings.Join(segs, "/") + ext
+}
+
+// redact removes credentials and tokens from s, including those sent with req.
+func redact(s string, req *http.Request) string {
+	if _, secret, ok := req.BasicAuth(); ok && secret != "" {
+		s = strings.Replace(s, secret, "REDACTED", -1)
+	}
+	if fields := strings.Fields(req.Header.Get("Authorization")); len(fields) == 2 {
+		s = strings.Replace(s, fields[1], "REDACTED", -1)
+	}
+	s = secretPattern.ReplaceAllString(s, "${1}REDACTED")
+	return bearerPattern.ReplaceAllString(s, "${1}REDACTED")
+}
@@ -0,0 +1,111 @@
+package reddit
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointTemplate(t *testing.T) {
+	cases := map[string]string{
+		"/api/v1/access_token":                         "/api/v1/access_token",
+		"/r/golang/new.json":                           "/r/{subreddit}/new.json",
+		"/comments/abc.json":                           "/comments/{id}.json",
+		"/r/golang/comments/abc/some_title/def":        "/r/{subreddit}/comments/{id}/{slug}/{id}",
+		"/user/spez/about.json":                        "/user/{user}/about.json",
+		"/user/spez/m/cats/top.json":                   "/user/{user}/m/{multi}/top.json",
+		"/api/multi/user/spez/m/cats/r/golang":         "/api/multi/user/{user}/m/{multi}/r/{subreddit}",
+		"/r/golang/wiki/config/automoderator.json":     "/r/{subreddit}/wiki/{page}.json",
+		"/r/golang/wiki/pages.json":                    "/r/{subreddit}/wiki/pages.json",
+		"/r/golang/wiki/settings/index":                "/r/{subreddit}/wiki/settings/{page}",
+		"/api/mod/conversations/2abc/archive":          "/api/mod/conversations/{id}/archive",
+		"/api/v1/golang/emoji/party_parrot":            "/api/v1/{subreddit}/emoji/{name}",
+		"/api/v1/golang/emojis/all":                    "/api/v1/{subreddit}/emojis/all",
+		"/api/info.json":                               "/api/info.json",
+		"/r/golang/api/flairselector/t3_abc/something": "/r/{subreddit}/api/flairselector/{fullname}/something",
+	}
+	for path, expected := range cases {
+		require.Equal(t, expected, endpointTemplate(path), path)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, RedditAuthURL, nil)
+	require.NoError(t, err)
+	req.SetBasicAuth("client", "s3cret")
+	require.Equal(t,
+		`http error 401 for x: REDACTED password=REDACTED&username=u {"access_token": "REDACTED"} bearer REDACTED`,
+		redact(`http error 401 for x: s3cret password=hunter2&username=u {"access_token": "abc"} bearer xyz`, req))
+}
+
+func TestConfig_Logger(t *testing.T) {
+	require := require.New(t)
+
+	limited := get("https://oauth.reddit.com/r/golang/top.json?limit=5&t=all", "Too Many Requests")
+	limited.statusCode = http.StatusTooManyRequests
+	limited.responseHeaders = map[string]string{"X-Ratelimit-Used": "600", "X-Ratelimit-Remaining": "0", "X-Ratelimit-Reset": "0"}
+	page1 := get("https://oauth.reddit.com/r/golang/top.json?limit=5&t=all", topPostsBody(0, 5))
+	page1.responseHeaders = map[string]string{"X-Ratelimit-Used": "1", "X-Ratelimit-Remaining": "599", "X-Ratelimit-Reset": "600"}
+	page2 := get("https://oauth.reddit.com/r/golang/top.json?after=4&count=5&limit=5&t=all", "denied test-token")
+	page2.statusCode = http.StatusForbidden
+	m := mock(authRequest, limited, page1, page2)
+	defer m.reset()
+
+	var out bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	c := testConfig
+	c.Configure(WithLogger(logger))
+	require.NoError(c.AuthScript(nil))
+	s := c.Stream(nil, &TopPosts{SubReddit: "golang", Duration: TopAll, ListingOptions: ListingOptions{Limit: 5}})
+	for s.Next() {
+	}
+	require.Error(s.Error())
+	require.Equal(2, s.Page())
+
+	require.Equal([]string{
+		`level=DEBUG msg="reddit request" method=POST endpoint=/api/v1/access_token status=200 latency=0s attempt=0 token=true`,
+		`level=WARN msg="reddit request rate limited" method=GET endpoint=/r/{subreddit}/top.json status=429 latency=0s ratelimit_remaining=0 attempt=0 page=1`,
+		`level=DEBUG msg="reddit request" method=GET endpoint=/r/{subreddit}/top.json status=200 latency=0s ratelimit_remaining=599 attempt=1 page=1`,
+		`level=ERROR msg="reddit request failed" method=GET endpoint=/r/{subreddit}/top.json status=403 latency=0s attempt=0 page=2 error="http error 403 for https://oauth.reddit.com/r/golang/top.json?after=4&count=5&limit=5&t=all: denied REDACTED"`,
+	}, strings.Split(strings.TrimSpace(out.String()), "\n"))
+	require.NotContains(out.String(), "pass")
+	require.NotContains(out.String(), "test-token")
+}
+
+func TestConfig_LoggerDecodeError(t *testing.T) {
+	require := require.New(t)
+
+	page1 := get("https://oauth.reddit.com/r/golang/top.json?limit=5&t=all", topPostsBody(0, 5))
+	page2 := get("https://oauth.reddit.com/r/golang/top.json?after=4&count=5&limit=5&t=all", `{"kind": "Listing", "data": {"children": [{"kind": "t3", "data": "oops"}]}}`)
+	m := mock(page1, page2)
+	defer m.reset()
+
+	var out bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c := authedConfig(m).Configure(WithLogger(logger))
+	s := c.Stream(nil, &TopPosts{SubReddit: "golang", Duration: TopAll, ListingOptions: ListingOptions{Limit: 5}})
+	for s.Next() {
+	}
+	require.Error(s.Error())
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(lines, 2)
+	require.Contains(lines[0], `level=DEBUG msg="reddit request"`)
+	require.Contains(lines[0], "page=1")
+	require.Contains(lines[1], `level=ERROR msg="reddit request failed" method=GET endpoint=/r/{subreddit}/top.json status=200`)
+	require.Contains(lines[1], "page=2")
+	require.Contains(lines[1], `error="failed to parse response from https://oauth.reddit.com/r/golang/top.json?after=4&count=5&limit=5&t=all: json: cannot unmarshal string into Go value of type reddit.Link"`)
+	require.NotContains(out.String(), "test-token")
+}
@@ -0,0 +1,255 @@
+package reddit
+
+import "html"
+
+// unescape undoes the HTML escaping reddit applies to URLs in JSON responses that are not
+// requested with raw_json=1.
+func unescape(s string) string { return html.UnescapeString(s) }
+
+// ImageSource is a single resolution of an image in a Preview.
+type ImageSource struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// RawURL returns URL with HTML entities unescaped.
+func (i ImageSource) RawURL() string { return unescape(i.URL) }
+
+// PreviewImage is an image in a Preview, along with its downscaled resolutions and variants
+// (gif, mp4, nsfw, obfuscated).
+type PreviewImage struct {
+	ID          string                  `json:"id"`
+	Source      ImageSource             `json:"source"`
+	Resolutions []ImageSource           `json:"resolutions"`
+	Variants    map[string]PreviewImage `json:"variants"`
+}
+
+// Best returns the largest resolution of the image that is at most maxWidth pixels wide. If maxWidth
+// is 0 the source image is returned. If maxWidth is smaller than all resolutions the smallest one is
+// returned, or the source image if there are no resolutions.
+func (p PreviewImage) Best(maxWidth int) ImageSource {
+	best := p.Source
+	if maxWidth <= 0 || best.Width <= maxWidth {
+		return best
+	}
+	found, smallest := false, -1
+	for i, r := range p.Resolutions {
+		if r.Width <= maxWidth && (!found || r.Width > best.Width) {
+			best, found = r, true
+		}
+		if smallest < 0 || r.Width < p.Resolutions[smallest].Width {
+			smallest = i
+		}
+	}
+	if !found && smallest >= 0 {
+		return p.Resolutions[smallest]
+	}
+	return best
+}
+
+// Preview holds the preview images reddit generates for a Link.
+type Preview struct {
+	Enabled            bool           `json:"enabled"`
+	Images             []PreviewImage `json:"images"`
+	RedditVideoPreview *RedditVideo   `json:"reddit_video_preview"`
+}
+
+// RedditVideo is a video hosted on v.redd.it.
+type RedditVideo struct {
+	BitrateKbps       int    `json:"bitrate_kbps"`
+	DashURL           string `json:"dash_url"`
+	Duration          int    `json:"duration"`
+	FallbackURL       string `json:"fallback_url"`
+	Height            int    `json:"height"`
+	HLSURL            string `json:"hls_url"`
+	IsGIF             bool   `json:"is_gif"`
+	ScrubberMediaURL  string `json:"scrubber_media_url"`
+	TranscodingStatus string `json:"transcoding_status"`
+	Width             int    `json:"width"`
+}
+
+// HLS returns the unescaped URL of the HLS playlist of the video.
+func (v *RedditVideo) HLS() string { return unescape(v.HLSURL) }
+
+// DASH returns the unescaped URL of the DASH manifest of the video.
+func (v *RedditVideo) DASH() string { return unescape(v.DashURL) }
+
+// Fallback returns the unescaped URL of the mp4 fallback of the video. It contains no audio.
+func (v *RedditVideo) Fallback() string { return unescape(v.FallbackURL) }
+
+// OEmbed is the oEmbed description of media embedded from an external provider.
+//
+// See https://oembed.com
+type OEmbed struct {
+	AuthorName      string `json:"author_name"`
+	AuthorURL       string `json:"author_url"`
+	Height          int    `json:"height"`
+	HTML            string `json:"html"`
+	ProviderName    string `json:"provider_name"`
+	ProviderURL     string `json:"provider_url"`
+	ThumbnailHeight int    `json:"thumbnail_height"`
+	ThumbnailURL    string `json:"thumbnail_url"`
+	ThumbnailWidth  int    `json:"thumbnail_width"`
+	Title           string `json:"title"`
+	Type            string `json:"type"`
+	Version         string `json:"version"`
+	Width           int    `json:"width"`
+}
+
+// Media is the media attached to a Link. Exactly one of OEmbed and RedditVideo is usually set.
+type Media struct {
+	Type        string       `json:"type"`
+	OEmbed      *OEmbed      `json:"oembed"`
+	RedditVideo *RedditVideo `json:"reddit_video"`
+}
+
+// MediaEmbed holds the HTML needed to embed the media of a Link.
+type MediaEmbed struct {
+	Content        string `json:"content"`
+	Width          int    `json:"width"`
+	Height         int    `json:"height"`
+	Scrolling      bool   `json:"scrolling"`
+	MediaDomainURL string `json:"media_domain_url"`
+}
+
+// GalleryItem is a single item of a gallery. MediaID is the key of the item in Link.MediaMetadata.
+type GalleryItem struct {
+	ID          int64  `json:"id"`
+	MediaID     string `json:"media_id"`
+	Caption     string `json:"caption"`
+	OutboundURL string `json:"outbound_url"`
+}
+
+// GalleryData holds the items of a gallery Link in the order they are displayed.
+type GalleryData struct {
+	Items []GalleryItem `json:"items"`
+}
+
+// MediaSource is a single resolution of an item in Link.MediaMetadata. URL is set for images,
+// GIF and MP4 for animated images.
+type MediaSource struct {
+	URL    string `json:"u,omitempty"`
+	GIF    string `json:"gif,omitempty"`
+	MP4    string `json:"mp4,omitempty"`
+	Width  int    `json:"x"`
+	Height int    `json:"y"`
+}
+
+// MediaItem describes media uploaded to reddit for galleries and inline images.
+type MediaItem struct {
+	ID      string        `json:"id"`
+	Status  string        `json:"status"`
+	Type    string        `json:"e"`
+	Mime    string        `json:"m"`
+	Source  MediaSource   `json:"s"`
+	Preview []MediaSource `json:"p"`
+	DashURL string        `json:"dashUrl"`
+	HLSURL  string        `json:"hlsUrl"`
+	IsGIF   bool          `json:"isGif"`
+}
+
+// GalleryImage is an item of a gallery along with its media.
+type GalleryImage struct {
+	GalleryItem
+	Mime   string
+	URL    string // The unescaped URL of the full size image, or its mp4 for animated images.
+	Width  int
+	Height int
+}
+
+// PollOption is an option in a poll. VoteCount is nil until the poll closes or the user votes.
+type PollOption struct {
+	ID        string `json:"id"`
+	Text      string `json:"text"`
+	VoteCount *int   `json:"vote_count"`
+}
+
+// PollData holds the options and results of a poll Link. The prediction fields are only set for
+// prediction polls, which are resolved by moderators.
+type PollData struct {
+	IsPrediction        bool         `json:"is_prediction"`
+	Options             []PollOption `json:"options"`
+	PredictionStatus    *string      `json:"prediction_status"`
+	ResolvedOptionID    *string      `json:"resolved_option_id"`
+	TotalStakeAmount    *int         `json:"total_stake_amount"`
+	TotalVoteCount      int          `json:"total_vote_count"`
+	TournamentID        *string      `json:"tournament_id"`
+	UserSelection       string       `json:"user_selection"`
+	UserWonAmount       *int         `json:"user_won_amount"`
+	VoteUpdatesRemained *int         `json:"vote_updates_remained"`
+	VotingEndTimestamp  int64        `json:"voting_end_timestamp"` // In milliseconds since the epoch.
+}
+
+// Original returns the Link that l is a crosspost of, or l itself if it is not a crosspost.
+func (l *Link) Original() *Link {
+	if len(l.CrosspostParentList) > 0 {
+		return &l.CrosspostParentList[0]
+	}
+	return l
+}
+
+// Images returns the preview images of l, if any.
+func (l *Link) Images() []PreviewImage {
+	if o := l.Original(); o.Preview != nil {
+		return o.Preview.Images
+	}
+	return nil
+}
+
+// BestImage returns the largest preview image resolution of l that is at most maxWidth pixels wide.
+// If maxWidth is 0 the full size image is returned, and if it is smaller than all resolutions the
+// smallest one is. It returns false if l has no preview images.
+func (l *Link) BestImage(maxWidth int) (ImageSource, bool) {
+	images := l.Images()
+	if len(images) == 0 {
+		return ImageSource{}, false
+	}
+	return images[0].Best(maxWidth), true
+}
+
+// Gallery returns the items of a gallery Link in display order. Items whose media has not been
+// processed by reddit are skipped.
+func (l *Link) Gallery() []GalleryImage {
+	o := l.Original()
+	if o.GalleryData == nil {
+		return nil
+	}
+	var images []GalleryImage
+	for _, item := range o.GalleryData.Items {
+		m, ok := o.MediaMetadata[item.MediaID]
+		if !ok || m.Status != "valid" {
+			continue
+		}
+		url := m.Source.URL
+		if url == "" {
+			url = m.Source.MP4
+		}
+		if url == "" {
+			url = m.Source.GIF
+		}
+		images = append(images, GalleryImage{
+			GalleryItem: item,
+			Mime:        m.Mime,
+			URL:         unescape(url),
+			Width:       m.Source.Width,
+			Height:      m.Source.Height,
+		})
+	}
+	return images
+}
+
+// Video returns the v.redd.it video of l, checking SecureMedia, Media and the preview in that order.
+// It returns nil if l has no video.
+func (l *Link) Video() *RedditVideo {
+	o := l.Original()
+	for _, m := range []*Media{o.SecureMedia, o.Media} {
+		if m != nil && m.RedditVideo != nil {
+			return m.RedditVideo
+		}
+	}
+	if o.Preview != nil {
+		return o.Preview.RedditVideoPreview
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package reddit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLink_Media(t *testing.T) {
+	require := require.New(t)
+
+	var thing Thing
+	readFixture(t, "media.json", &thing)
+	children := thing.Data.(*Listing).Children
+	require.Len(children, 4)
+	gallery, video, poll, crosspost := children[0].Data.(*Link), children[1].Data.(*Link), children[2].Data.(*Link), children[3].Data.(*Link)
+
+	require.True(gallery.IsGallery)
+	require.Equal([]GalleryImage{
+		{
+			GalleryItem: GalleryItem{ID: 182719, MediaID: "b2bq9x1", Caption: "Keynote"},
+			Mime:        "image/jpg",
+			URL:         "https://preview.redd.it/b2bq9x1.jpg?width=4032&format=pjpg&auto=webp&s=555",
+			Width:       4032,
+			Height:      3024,
+		},
+		{
+			GalleryItem: GalleryItem{ID: 182720, MediaID: "a1aq8x1", OutboundURL: "https://go.dev"},
+			Mime:        "image/gif",
+			URL:         "https://preview.redd.it/a1aq8x1.gif?format=mp4&s=222",
+			Width:       480,
+			Height:      480,
+		},
+	}, gallery.Gallery())
+	require.Nil(gallery.Video())
+	_, ok := gallery.BestImage(0)
+	require.False(ok)
+
+	v := video.Video()
+	require.NotNil(v)
+	require.Equal("https://v.redd.it/q7w8e9r0/HLSPlaylist.m3u8?a=1700000000%2CZmFrZQ%3D%3D&v=1&f=sd", v.HLS())
+	require.Equal("https://v.redd.it/q7w8e9r0/DASHPlaylist.mpd?a=1700000000%2CZmFrZQ%3D%3D&v=1&f=sd", v.DASH())
+	require.Equal("https://v.redd.it/q7w8e9r0/DASH_720.mp4?source=fallback", v.Fallback())
+	img, ok := video.BestImage(700)
+	require.True(ok)
+	require.Equal(640, img.Width)
+	require.Equal("https://external-preview.redd.it/pQ1w.png?width=640&crop=smart&s=888", img.RawURL())
+	img, _ = video.BestImage(0)
+	require.Equal(1280, img.Width)
+	img, _ = video.BestImage(50)
+	require.Equal(108, img.Width)
+	require.Equal(4032, PreviewImage{Source: ImageSource{Width: 4032}}.Best(50).Width)
+
+	require.Equal(150, poll.PollData.TotalVoteCount)
+	require.Equal("24581012", poll.PollData.UserSelection)
+	require.Len(poll.PollData.Options, 2)
+	require.Equal(120, *poll.PollData.Options[0].VoteCount)
+	require.Nil(poll.PollData.PredictionStatus)
+	require.Nil(poll.PollData.ResolvedOptionID)
+
+	require.Equal(Fullname("t3_x2vid2"), crosspost.CrosspostParent)
+	require.Equal(Fullname("t3_x2vid2"), crosspost.Original().Name)
+	require.Equal(v.HLS(), crosspost.Video().HLS())
+
+	requireRoundTrip(t, &thing, &Thing{})
+}
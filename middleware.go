@@ -0,0 +1,84 @@
+package reddit
+
+import (
+	"net/http"
+	"time"
+)
+
+// RequestInfo describes an attempt at an HTTP request made by a Config. It is passed to Middleware hooks.
+type RequestInfo struct {
+	Request      *http.Request  // The request being made.
+	Token        bool           // True for requests made by AuthScript to obtain a token.
+	StreamPage   int            // Number of the Listing page fetched by a Stream, starting at 1. Zero for other requests.
+	Attempt      int            // Number of earlier attempts at the request, which were rate limited.
+	Start        time.Time      // Time at which the attempt started, after waiting for the Limiter.
+	Duration     time.Duration  // Time taken to send the request and read the response. Zero in BeforeRequest.
+	Response     *http.Response // The response, whose body has been read into Body. Nil if there is none.
+	Body         []byte         // Body of the response.
+	RateLimit    RateLimit      // Rate limit state reported in the response, if HasRateLimit is true.
+	HasRateLimit bool
+	// Err is the error returned for the request, set in AfterResponse for the final response. It includes
+	// errors reported by reddit in a 200 OK response and responses that could not be decoded. Err is nil
+	// for successful requests and for rate limited responses that are retried.
+	Err error
+}
+
+// Endpoint returns the path of the request with names and IDs replaced by placeholders, like
+// /r/{subreddit}/comments/{id}. It is suitable as a low cardinality label for logs and metrics.
+func (i *RequestInfo) Endpoint() string { return endpointTemplate(i.Request.URL.Path) }
+
+// Redact returns s with credentials and tokens removed, including those sent with the request. Use it
+// before logging or exporting error messages and response bodies.
+func (i *RequestInfo) Redact(s string) string { return redact(s, i.Request) }
+
+// Middleware holds hooks that are called around every HTTP request made by a Config with WithMiddleware.
+// This includes token requests, Get, Post and all other API calls, resolving share links and uploading
+// emoji images. Any of the hooks may be nil.
+type Middleware struct {
+	// BeforeRequest is called before each attempt at a request is sent. It may modify info.Request, for
+	// example to add headers. If it returns an error the request is not sent and the error is returned.
+	BeforeRequest func(info *RequestInfo) error
+	// AfterResponse is called after each response is received, including rate limited responses that
+	// are retried.
+	AfterResponse func(info *RequestInfo)
+	// OnError is called with the error returned for a request that could not be sent, that got a
+	// response with a status other than 200 OK, or whose response reported errors or could not be
+	// decoded. It is called after AfterResponse for requests that got a response.
+	OnError func(info *RequestInfo, err error)
+}
+
+// WithMiddleware adds m to the middleware of a Config. BeforeRequest hooks are called in the order they
+// were added, AfterResponse and OnError hooks in the reverse order.
+func WithMiddleware(m Middleware) Option {
+	return func(s *settings) { s.middleware = append(s.middleware, m) }
+}
+
+func (c *Config) beforeRequest(info *RequestInfo) error {
+	for _, m := range c.settings.middleware {
+		if m.BeforeRequest == nil {
+			continue
+		}
+		if err := m.BeforeRequest(info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Config) afterResponse(info *RequestInfo) {
+	for i := len(c.settings.middleware) - 1; i >= 0; i-- {
+		if m := c.settings.middleware[i]; m.AfterResponse != nil {
+			m.AfterResponse(info)
+		}
+	}
+}
+
+// onError calls the OnError hooks and returns err.
+func (c *Config) onError(info *RequestInfo, err error) error {
+	for i := len(c.settings.middleware) - 1; i >= 0; i-- {
+		if m := c.settings.middleware[i]; m.OnError != nil {
+			m.OnError(info, err)
+		}
+	}
+	return err
+}
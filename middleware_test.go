@@ -0,0 +1,143 @@
+package reddit
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Middleware(t *testing.T) {
+	require := require.New(t)
+
+	const about = "https://oauth.reddit.com/r/golang/about.json"
+	limited := get(about, "Too Many Requests")
+	limited.statusCode = http.StatusTooManyRequests
+	limited.responseHeaders = map[string]string{"X-Ratelimit-Used": "600", "X-Ratelimit-Remaining": "0", "X-Ratelimit-Reset": "0"}
+	ok := get(about, `{"kind": "t5", "data": {"display_name": "golang"}}`)
+	ok.responseHeaders = map[string]string{"X-Ratelimit-Used": "1", "X-Ratelimit-Remaining": "599", "X-Ratelimit-Reset": "600"}
+	ok.headers = map[string]string{"X-Trace": "1", "Authorization": "bearer test-token"}
+	failed := post("https://oauth.reddit.com/api/subscribe", "action=sub&skip_initial_defaults=true&sr_name=golang", "oops")
+	failed.statusCode = http.StatusInternalServerError
+	m := mock(authRequest, limited, ok, failed)
+	defer m.reset()
+
+	var events []string
+	record := func(name string) Middleware {
+		return Middleware{
+			BeforeRequest: func(info *RequestInfo) error {
+				events = append(events, fmt.Sprintf("%s before %s %s token=%v attempt=%d", name, info.Request.Method, info.Request.URL.Path, info.Token, info.Attempt))
+				return nil
+			},
+			AfterResponse: func(info *RequestInfo) {
+				events = append(events, fmt.Sprintf("%s after %d remaining=%v", name, info.Response.StatusCode, info.RateLimit.Remaining))
+			},
+			OnError: func(info *RequestInfo, err error) {
+				events = append(events, fmt.Sprintf("%s error %v", name, err))
+			},
+		}
+	}
+	trace := Middleware{BeforeRequest: func(info *RequestInfo) error {
+		info.Request.Header.Set("X-Trace", fmt.Sprint(info.Attempt))
+		return nil
+	}}
+	c := testConfig
+	c.Configure(WithMiddleware(record("a")), WithMiddleware(trace), WithMiddleware(record("b")))
+
+	require.NoError(c.AuthScript(nil))
+	_, err := c.SubReddit(nil, "golang")
+	require.NoError(err)
+	require.Error(c.Subscribe(nil, "golang"))
+	require.Equal([]string{
+		"a before POST /api/v1/access_token token=true attempt=0",
+		"b before POST /api/v1/access_token token=true attempt=0",
+		"b after 200 remaining=0",
+		"a after 200 remaining=0",
+		"a before GET /r/golang/about.json token=false attempt=0",
+		"b before GET /r/golang/about.json token=false attempt=0",
+		"b after 429 remaining=0",
+		"a after 429 remaining=0",
+		"a before GET /r/golang/about.json token=false attempt=1",
+		"b before GET /r/golang/about.json token=false attempt=1",
+		"b after 200 remaining=599",
+		"a after 200 remaining=599",
+		"a before POST /api/subscribe token=false attempt=0",
+		"b before POST /api/subscribe token=false attempt=0",
+		"b after 500 remaining=0",
+		"a after 500 remaining=0",
+		"b error http error 500 for https://oauth.reddit.com/api/subscribe: oops",
+		"a error http error 500 for https://oauth.reddit.com/api/subscribe: oops",
+	}, events)
+
+	// An error from BeforeRequest stops the request.
+	m.reset()
+	m = mock()
+	defer m.reset()
+	events = nil
+	c.Configure(WithMiddleware(Middleware{BeforeRequest: func(*RequestInfo) error { return fmt.Errorf("blocked") }}))
+	require.EqualError(c.Subscribe(nil, "golang"), "blocked")
+	require.Equal([]string{
+		"a before POST /api/subscribe token=false attempt=0",
+		"b before POST /api/subscribe token=false attempt=0",
+		"b error blocked",
+		"a error blocked",
+	}, events)
+}
+
+func TestConfig_MiddlewareTiming(t *testing.T) {
+	m := mock(get("https://oauth.reddit.com/r/golang/about.json", `{"kind": "t5", "data": {}}`))
+	defer m.reset()
+
+	fake := clock.(*clockwork.FakeClock)
+	var info RequestInfo
+	c := authedConfig(m).Configure(WithMiddleware(Middleware{
+		// Time spent in hooks is not part of the duration.
+		BeforeRequest: func(*RequestInfo) error { fake.Advance(time.Second); return nil },
+		AfterResponse: func(i *RequestInfo) { info = *i },
+	}))
+	_, err := c.SubReddit(nil, "golang")
+	require.NoError(t, err)
+	require.Equal(t, m.time, info.Start)
+	require.Equal(t, time.Duration(0), info.Duration)
+	require.Equal(t, `{"kind": "t5", "data": {}}`, string(info.Body))
+	require.False(t, info.HasRateLimit)
+}
+
+func TestConfig_MiddlewareResponseErrors(t *testing.T) {
+	require := require.New(t)
+
+	apiError := post("https://oauth.reddit.com/api/subscribe", "action=sub&skip_initial_defaults=true&sr_name=golang",
+		`{"json": {"errors": [["SUBREDDIT_NOEXIST", "that subreddit doesn't exist", "sr_name"]]}}`)
+	undecodable := get("https://oauth.reddit.com/r/golang/about.json", `{"kind": "t5", "data": [`)
+	share := response{statusCode: http.StatusFound, requestURL: "https://www.reddit.com/r/golang/s/abc",
+		responseHeaders: map[string]string{"Location": "https://www.reddit.com/r/golang/comments/xyz/title/"}}
+	m := mock(apiError, undecodable, share)
+	defer m.reset()
+
+	var events []string
+	c := authedConfig(m).Configure(WithMiddleware(Middleware{
+		AfterResponse: func(info *RequestInfo) {
+			events = append(events, fmt.Sprintf("after %s %d err=%v", info.Request.URL.Path, info.Response.StatusCode, info.Err != nil))
+		},
+		OnError: func(info *RequestInfo, err error) {
+			require.Equal(info.Err, err)
+			events = append(events, fmt.Sprintf("error %s", info.Request.URL.Path))
+		},
+	}))
+	require.Error(c.Subscribe(nil, "golang"))
+	_, err := c.SubReddit(nil, "golang")
+	require.Error(err)
+	target, err := c.ResolveShareLink(nil, &Target{SubReddit: "golang", ShareCode: "abc"})
+	require.NoError(err)
+	require.Equal(Fullname("t3_xyz"), target.Link)
+	require.Equal([]string{
+		"after /api/subscribe 200 err=true",
+		"error /api/subscribe",
+		"after /r/golang/about.json 200 err=true",
+		"error /r/golang/about.json",
+		"after /r/golang/s/abc 302 err=false",
+	}, events)
+}
@@ -0,0 +1,188 @@
+package reddit
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/go-querystring/query"
+)
+
+// DistinguishType is the way in which a moderator distinguishes a Link or Comment.
+type DistinguishType string
+
+// DistinguishModerator, DistinguishNone, DistinguishAdmin and DistinguishSpecial are supported values for
+// Config.Distinguish. DistinguishNone removes an existing distinction.
+const (
+	DistinguishModerator DistinguishType = "yes"
+	DistinguishNone      DistinguishType = "no"
+	DistinguishAdmin     DistinguishType = "admin"
+	DistinguishSpecial   DistinguishType = "special"
+)
+
+// CommentSort represents a sort order for the comments of a Link.
+type CommentSort string
+
+// Supported comment sort orders. SortBlank clears a suggested sort set with Config.SetSuggestedSort.
+const (
+	SortConfidence    CommentSort = "confidence"
+	SortTop           CommentSort = "top"
+	SortNew           CommentSort = "new"
+	SortControversial CommentSort = "controversial"
+	SortOld           CommentSort = "old"
+	SortRandom        CommentSort = "random"
+	SortQA            CommentSort = "qa"
+	SortLive          CommentSort = "live"
+	SortBlank         CommentSort = "blank"
+)
+
+// linksAndComments are the kinds of Things most moderation actions apply to.
+var linksAndComments = []Kind{KindLink, KindComment}
+
+// modPost performs a moderation request against path with the fullname of the target Thing as id. It fails
+// without making a request if the fullname is not of one of the provided kinds.
+func (c *Config) modPost(client *http.Client, path string, fullname Fullname, form url.Values, kinds ...Kind) error {
+	if err := fullname.expect(kinds...); err != nil {
+		return err
+	}
+	if form == nil {
+		form = url.Values{}
+	}
+	form.Set("id", string(fullname))
+	return c.Post(client, RedditAPIURL+path, form, nil)
+}
+
+// Approve approves the Link or Comment with the provided fullname.
+func (c *Config) Approve(client *http.Client, fullname Fullname) error {
+	return c.modPost(client, "/api/approve", fullname, nil, linksAndComments...)
+}
+
+// Remove removes the Link or Comment with the provided fullname. If spam is true the item is also
+// used to train the subreddit's spam filter.
+func (c *Config) Remove(client *http.Client, fullname Fullname, spam bool) error {
+	return c.modPost(client, "/api/remove", fullname, url.Values{"spam": {strconv.FormatBool(spam)}}, linksAndComments...)
+}
+
+// Distinguish distinguishes the Link or Comment with the provided fullname. If sticky is true and the
+// target is a top level comment it is also stickied to the top of the comments.
+func (c *Config) Distinguish(client *http.Client, fullname Fullname, how DistinguishType, sticky bool) error {
+	form := url.Values{"api_type": {"json"}, "how": {string(how)}}
+	if sticky {
+		form.Set("sticky", "true")
+	}
+	return c.modPost(client, "/api/distinguish", fullname, form, linksAndComments...)
+}
+
+// Sticky stickies or unstickies the Link with the provided fullname. slot is the position (1 or 2) of
+// the sticky on the subreddit. A slot of 0 lets reddit pick the position.
+func (c *Config) Sticky(client *http.Client, fullname Fullname, state bool, slot int) error {
+	form := url.Values{"api_type": {"json"}, "state": {strconv.FormatBool(state)}}
+	if slot != 0 {
+		form.Set("num", strconv.Itoa(slot))
+	}
+	return c.modPost(client, "/api/set_subreddit_sticky", fullname, form, KindLink)
+}
+
+// Lock prevents new comments on the Link or replies to the Comment with the provided fullname.
+func (c *Config) Lock(client *http.Client, fullname Fullname) error {
+	return c.modPost(client, "/api/lock", fullname, nil, linksAndComments...)
+}
+
+// Unlock reverses Lock.
+func (c *Config) Unlock(client *http.Client, fullname Fullname) error {
+	return c.modPost(client, "/api/unlock", fullname, nil, linksAndComments...)
+}
+
+// MarkNSFW marks the Link with the provided fullname as not safe for work.
+func (c *Config) MarkNSFW(client *http.Client, fullname Fullname) error {
+	return c.modPost(client, "/api/marknsfw", fullname, nil, KindLink)
+}
+
+// UnmarkNSFW reverses MarkNSFW.
+func (c *Config) UnmarkNSFW(client *http.Client, fullname Fullname) error {
+	return c.modPost(client, "/api/unmarknsfw", fullname, nil, KindLink)
+}
+
+// MarkSpoiler marks the Link with the provided fullname as a spoiler.
+func (c *Config) MarkSpoiler(client *http.Client, fullname Fullname) error {
+	return c.modPost(client, "/api/spoiler", fullname, nil, KindLink)
+}
+
+// UnmarkSpoiler reverses MarkSpoiler.
+func (c *Config) UnmarkSpoiler(client *http.Client, fullname Fullname) error {
+	return c.modPost(client, "/api/unspoiler", fullname, nil, KindLink)
+}
+
+// IgnoreReports prevents future reports on the Link or Comment with the provided fullname from
+// showing up in the moderation queue.
+func (c *Config) IgnoreReports(client *http.Client, fullname Fullname) error {
+	return c.modPost(client, "/api/ignore_reports", fullname, nil, linksAndComments...)
+}
+
+// UnignoreReports reverses IgnoreReports.
+func (c *Config) UnignoreReports(client *http.Client, fullname Fullname) error {
+	return c.modPost(client, "/api/unignore_reports", fullname, nil, linksAndComments...)
+}
+
+// SetContestMode enables or disables contest mode for the comments of the Link with the provided fullname.
+func (c *Config) SetContestMode(client *http.Client, fullname Fullname, enabled bool) error {
+	form := url.Values{"api_type": {"json"}, "state": {strconv.FormatBool(enabled)}}
+	return c.modPost(client, "/api/set_contest_mode", fullname, form, KindLink)
+}
+
+// SetSuggestedSort sets the suggested comment sort for the Link with the provided fullname. Use
+// SortBlank to clear the suggested sort.
+func (c *Config) SetSuggestedSort(client *http.Client, fullname Fullname, sort CommentSort) error {
+	form := url.Values{"api_type": {"json"}, "sort": {string(sort)}}
+	return c.modPost(client, "/api/set_suggested_sort", fullname, form, KindLink)
+}
+
+// BanOptions control the length of and reasons given for a ban.
+type BanOptions struct {
+	Duration int      `url:"duration,omitempty"`    // Length of the ban in days. 0 bans permanently.
+	Note     string   `url:"note,omitempty"`        // Note visible only to moderators.
+	Reason   string   `url:"ban_reason,omitempty"`  // Short reason shown in the list of banned users.
+	Message  string   `url:"ban_message,omitempty"` // Message sent to the banned user.
+	Context  Fullname `url:"ban_context,omitempty"` // Fullname of the Link or Comment that led to the ban.
+}
+
+// relationship adds or removes a relationship of the given type between user and subreddit.
+func (c *Config) relationship(client *http.Client, action, subreddit, user, kind string, form url.Values) error {
+	if form == nil {
+		form = url.Values{}
+	}
+	form.Set("api_type", "json")
+	form.Set("name", user)
+	form.Set("type", kind)
+	return c.Post(client, subredditAPIURL(subreddit, action), form, nil)
+}
+
+// Ban bans user from subreddit.
+func (c *Config) Ban(client *http.Client, subreddit, user string, opts BanOptions) error {
+	form, err := query.Values(opts)
+	if err != nil {
+		return err
+	}
+	return c.relationship(client, "friend", subreddit, user, "banned", form)
+}
+
+// Unban reverses Ban.
+func (c *Config) Unban(client *http.Client, subreddit, user string) error {
+	return c.relationship(client, "unfriend", subreddit, user, "banned", nil)
+}
+
+// Mute prevents user from messaging the moderators of subreddit. The note is visible only to moderators.
+// Unlike Ban, reddit's muted relationship takes no duration and mutes for 72 hours. Use MuteModmail on a
+// conversation with user to mute for 7 or 28 days.
+func (c *Config) Mute(client *http.Client, subreddit, user, note string) error {
+	form := url.Values{}
+	if note != "" {
+		form.Set("note", note)
+	}
+	return c.relationship(client, "friend", subreddit, user, "muted", form)
+}
+
+// Unmute reverses Mute.
+func (c *Config) Unmute(client *http.Client, subreddit, user string) error {
+	return c.relationship(client, "unfriend", subreddit, user, "muted", nil)
+}
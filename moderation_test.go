@@ -0,0 +1,46 @@
+package reddit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Moderation(t *testing.T) {
+	m := mock(
+		post("https://oauth.reddit.com/api/approve", "id=t3_abc", "{}"),
+		post("https://oauth.reddit.com/api/remove", "id=t1_def&spam=true", "{}"),
+		post("https://oauth.reddit.com/api/distinguish", "api_type=json&how=yes&id=t1_def&sticky=true", `{"json": {"errors": []}}`),
+		post("https://oauth.reddit.com/api/set_subreddit_sticky", "api_type=json&id=t3_abc&num=2&state=true", `{"json": {"errors": []}}`),
+		post("https://oauth.reddit.com/api/lock", "id=t3_abc", "{}"),
+		post("https://oauth.reddit.com/api/set_suggested_sort", "api_type=json&id=t3_abc&sort=qa", `{"json": {"errors": []}}`),
+		post("https://oauth.reddit.com/r/golang/api/friend",
+			"api_type=json&ban_message=Please+read+the+rules&ban_reason=spam&duration=7&name=spammer&note=repeat+offender&type=banned",
+			`{"json": {"errors": []}}`),
+		post("https://oauth.reddit.com/r/golang/api/unfriend", "api_type=json&name=spammer&type=muted", `{"json": {"errors": []}}`),
+	)
+	defer m.reset()
+
+	require := require.New(t)
+	c := authedConfig(m)
+
+	require.NoError(c.Approve(nil, "t3_abc"))
+	require.NoError(c.Remove(nil, "t1_def", true))
+	require.NoError(c.Distinguish(nil, "t1_def", DistinguishModerator, true))
+	require.NoError(c.Sticky(nil, "t3_abc", true, 2))
+	require.NoError(c.Lock(nil, "t3_abc"))
+	require.NoError(c.SetSuggestedSort(nil, "t3_abc", SortQA))
+	require.NoError(c.Ban(nil, "golang", "spammer", BanOptions{
+		Duration: 7, Note: "repeat offender", Reason: "spam", Message: "Please read the rules",
+	}))
+	require.NoError(c.Unmute(nil, "golang", "spammer"))
+}
+
+func TestConfig_ModerationAPIErrors(t *testing.T) {
+	m := mock(post("https://oauth.reddit.com/r/nosuchsub/api/friend", "api_type=json&name=spammer&type=banned",
+		`{"json": {"errors": [["SUBREDDIT_NOEXIST", "that subreddit doesn't exist", "sr_name"]]}}`))
+	defer m.reset()
+
+	err := authedConfig(m).Ban(nil, "nosuchsub", "spammer", BanOptions{})
+	require.Equal(t, APIErrors{{Code: "SUBREDDIT_NOEXIST", Message: "that subreddit doesn't exist", Field: "sr_name"}}, err)
+}
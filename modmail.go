@@ -0,0 +1,255 @@
+package reddit
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+// ModmailState filters the modmail conversations returned by Config.ModmailConversations.
+type ModmailState string
+
+// Supported states for a ModmailQuery.
+const (
+	ModmailAll           ModmailState = "all"
+	ModmailNew           ModmailState = "new"
+	ModmailInProgress    ModmailState = "inprogress"
+	ModmailArchived      ModmailState = "archived"
+	ModmailHighlighted   ModmailState = "highlighted"
+	ModmailMod           ModmailState = "mod"
+	ModmailNotifications ModmailState = "notifications"
+	ModmailAppeals       ModmailState = "appeals"
+	ModmailJoinRequests  ModmailState = "join_requests"
+)
+
+// ModmailParticipant is a user taking part in a modmail conversation.
+type ModmailParticipant struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	IsAdmin       bool   `json:"isAdmin"`
+	IsApproved    bool   `json:"isApproved"`
+	IsDeleted     bool   `json:"isDeleted"`
+	IsHidden      bool   `json:"isHidden"`
+	IsMod         bool   `json:"isMod"`
+	IsOp          bool   `json:"isOp"`
+	IsParticipant bool   `json:"isParticipant"`
+}
+
+// ModmailMessage is a single message in a modmail conversation.
+type ModmailMessage struct {
+	ID              string             `json:"id"`
+	Author          ModmailParticipant `json:"author"`
+	Body            string             `json:"body"` // HTML
+	BodyMarkdown    string             `json:"bodyMarkdown"`
+	Date            time.Time          `json:"date"`
+	IsInternal      bool               `json:"isInternal"`
+	ParticipatingAs string             `json:"participatingAs"`
+}
+
+// ModmailOwner is the subreddit that owns a modmail conversation.
+type ModmailOwner struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Type        string `json:"type"`
+}
+
+// ModmailObjectID refers to an object, such as a message, that is part of a modmail conversation.
+type ModmailObjectID struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+}
+
+// ModmailConversation is a single modmail conversation. Messages holds the messages of the
+// conversation in the order they were sent.
+type ModmailConversation struct {
+	ID             string               `json:"id"`
+	Authors        []ModmailParticipant `json:"authors"`
+	IsAuto         bool                 `json:"isAuto"`
+	IsHighlighted  bool                 `json:"isHighlighted"`
+	IsInternal     bool                 `json:"isInternal"`
+	IsRepliable    bool                 `json:"isRepliable"`
+	LastModUpdate  time.Time            `json:"lastModUpdate"`
+	LastUnread     time.Time            `json:"lastUnread"`
+	LastUpdated    time.Time            `json:"lastUpdated"`
+	LastUserUpdate time.Time            `json:"lastUserUpdate"`
+	NumMessages    int                  `json:"numMessages"`
+	ObjIDs         []ModmailObjectID    `json:"objIds"`
+	Owner          ModmailOwner         `json:"owner"`
+	Participant    ModmailParticipant   `json:"participant"`
+	State          int                  `json:"state"`
+	Subject        string               `json:"subject"`
+	Messages       []*ModmailMessage    `json:"-"`
+}
+
+// modmailResponse is the shape of all modmail responses. Listings populate Conversations and
+// ConversationIDs, while requests for a single conversation populate Conversation.
+type modmailResponse struct {
+	Conversation    *ModmailConversation            `json:"conversation"`
+	Conversations   map[string]*ModmailConversation `json:"conversations"`
+	ConversationIDs []string                        `json:"conversationIds"`
+	Messages        map[string]*ModmailMessage      `json:"messages"`
+	ViewerID        string                          `json:"viewerId"`
+}
+
+// attach populates the Messages of conv from the messages in the response.
+func (r *modmailResponse) attach(conv *ModmailConversation) {
+	conv.Messages = nil
+	for _, o := range conv.ObjIDs {
+		if m, ok := r.Messages[o.ID]; ok && o.Key == "messages" {
+			conv.Messages = append(conv.Messages, m)
+		}
+	}
+}
+
+// ModmailQuery is a query for a page of modmail conversations. It implements URLer.
+type ModmailQuery struct {
+	After  string       `url:"after,omitempty"`  // ID of the conversation to start after.
+	Entity string       `url:"entity,omitempty"` // Comma separated subreddits to list conversations from.
+	Limit  int          `url:"limit,omitempty"`
+	Sort   string       `url:"sort,omitempty"` // One of recent, mod, user or unread.
+	State  ModmailState `url:"state,omitempty"`
+}
+
+// URL returns the URL to use when fetching modmail conversations.
+func (q *ModmailQuery) URL() (string, error) {
+	v, err := query.Values(q)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/api/mod/conversations?%s", RedditAPIURL, v.Encode()), nil
+}
+
+// ModmailPage is a single page of modmail conversations.
+type ModmailPage struct {
+	Conversations []*ModmailConversation // Conversations in the order returned by reddit.
+	ViewerID      string                 // Fullname of the authenticated user.
+}
+
+// ModmailConversations fetches a page of modmail conversations. q.After is updated to the ID of the last
+// conversation returned, so calling ModmailConversations repeatedly with the same query pages through all
+// conversations. An empty page is returned once all conversations have been read.
+func (c *Config) ModmailConversations(client *http.Client, q *ModmailQuery) (*ModmailPage, error) {
+	u, err := q.URL()
+	if err != nil {
+		return nil, err
+	}
+	var r modmailResponse
+	if err := c.Get(client, u, &r); err != nil {
+		return nil, err
+	}
+	page := &ModmailPage{ViewerID: r.ViewerID}
+	for _, id := range r.ConversationIDs {
+		conv, ok := r.Conversations[id]
+		if !ok {
+			return nil, fmt.Errorf("conversation %s missing from response", id)
+		}
+		r.attach(conv)
+		page.Conversations = append(page.Conversations, conv)
+	}
+	if n := len(r.ConversationIDs); n > 0 {
+		q.After = r.ConversationIDs[n-1]
+	}
+	return page, nil
+}
+
+func modmailURL(id, action string) string {
+	u := fmt.Sprintf("%s/api/mod/conversations/%s", RedditAPIURL, url.PathEscape(id))
+	if action != "" {
+		u += "/" + action
+	}
+	return u
+}
+
+// conversation performs a request that returns a single conversation.
+func (c *Config) conversation(client *http.Client, method, url string, form url.Values) (*ModmailConversation, error) {
+	var r modmailResponse
+	if err := c.do(client, method, url, form, &r); err != nil {
+		return nil, err
+	}
+	if r.Conversation == nil {
+		return nil, fmt.Errorf("no conversation in response from %s", url)
+	}
+	r.attach(r.Conversation)
+	return r.Conversation, nil
+}
+
+// ModmailConversation fetches the modmail conversation with the provided ID. If markRead is true the
+// conversation is also marked as read.
+func (c *Config) ModmailConversation(client *http.Client, id string, markRead bool) (*ModmailConversation, error) {
+	return c.conversation(client, http.MethodGet, modmailURL(id, "")+"?markRead="+strconv.FormatBool(markRead), nil)
+}
+
+// ModmailReplyOptions control how a modmail reply is sent.
+type ModmailReplyOptions struct {
+	AuthorHidden bool // Reply as the subreddit instead of the authenticated moderator.
+	Internal     bool // Send a private moderator note that is not visible to the user.
+}
+
+// ReplyModmail replies to the modmail conversation with the provided ID, returning the updated conversation.
+func (c *Config) ReplyModmail(client *http.Client, id, body string, opts ModmailReplyOptions) (*ModmailConversation, error) {
+	form := url.Values{
+		"body":           {body},
+		"isAuthorHidden": {strconv.FormatBool(opts.AuthorHidden)},
+		"isInternal":     {strconv.FormatBool(opts.Internal)},
+	}
+	return c.conversation(client, http.MethodPost, modmailURL(id, ""), form)
+}
+
+// ArchiveModmail archives the modmail conversation with the provided ID.
+func (c *Config) ArchiveModmail(client *http.Client, id string) error {
+	return c.Post(client, modmailURL(id, "archive"), url.Values{}, nil)
+}
+
+// UnarchiveModmail reverses ArchiveModmail.
+func (c *Config) UnarchiveModmail(client *http.Client, id string) error {
+	return c.Post(client, modmailURL(id, "unarchive"), url.Values{}, nil)
+}
+
+// HighlightModmail highlights the modmail conversation with the provided ID.
+func (c *Config) HighlightModmail(client *http.Client, id string) error {
+	return c.Post(client, modmailURL(id, "highlight"), url.Values{}, nil)
+}
+
+// UnhighlightModmail reverses HighlightModmail.
+func (c *Config) UnhighlightModmail(client *http.Client, id string) error {
+	return c.do(client, http.MethodDelete, modmailURL(id, "highlight"), nil, nil)
+}
+
+// MuteModmail mutes the non moderator participant of the modmail conversation with the provided ID
+// for the given number of hours. reddit accepts 72, 168 and 672 hours.
+func (c *Config) MuteModmail(client *http.Client, id string, hours int) error {
+	return c.Post(client, modmailURL(id, "mute"), url.Values{"num_hours": {strconv.Itoa(hours)}}, nil)
+}
+
+// UnmuteModmail reverses MuteModmail.
+func (c *Config) UnmuteModmail(client *http.Client, id string) error {
+	return c.Post(client, modmailURL(id, "unmute"), url.Values{}, nil)
+}
+
+// MarkModmailRead marks the modmail conversations with the provided IDs as read.
+func (c *Config) MarkModmailRead(client *http.Client, ids ...string) error {
+	return c.Post(client, modmailURL("read", ""), url.Values{"conversationIds": {strings.Join(ids, ",")}}, nil)
+}
+
+// MarkModmailUnread marks the modmail conversations with the provided IDs as unread.
+func (c *Config) MarkModmailUnread(client *http.Client, ids ...string) error {
+	return c.Post(client, modmailURL("unread", ""), url.Values{"conversationIds": {strings.Join(ids, ",")}}, nil)
+}
+
+// BulkReadModmail marks all modmail conversations of the given state in the provided subreddits as read.
+// It returns the IDs of the conversations that were marked read.
+func (c *Config) BulkReadModmail(client *http.Client, state ModmailState, subreddits ...string) ([]string, error) {
+	form := url.Values{"entity": {strings.Join(subreddits, ",")}, "state": {string(state)}}
+	var r struct {
+		ConversationIDs []string `json:"conversation_ids"`
+	}
+	if err := c.Post(client, modmailURL("bulk", "read"), form, &r); err != nil {
+		return nil, err
+	}
+	return r.ConversationIDs, nil
+}
@@ -0,0 +1,94 @@
+package reddit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const modmailListBody = `{
+	"conversations": {
+		"2": {"id": "2", "subject": "second", "numMessages": 1, "objIds": [{"id": "m3", "key": "messages"}],
+			"owner": {"id": "t5_sub", "displayName": "golang", "type": "subreddit"},
+			"lastUpdated": "2019-04-03T20:31:22.431375+00:00", "lastUnread": null, "state": 1},
+		"1": {"id": "1", "subject": "first", "numMessages": 2, "isHighlighted": true,
+			"objIds": [{"id": "m1", "key": "messages"}, {"id": "a1", "key": "modActions"}, {"id": "m2", "key": "messages"}],
+			"participant": {"id": 42, "name": "user", "isOp": true}}
+	},
+	"conversationIds": ["1", "2"],
+	"messages": {
+		"m1": {"id": "m1", "bodyMarkdown": "hello", "author": {"name": "user", "isOp": true}},
+		"m2": {"id": "m2", "bodyMarkdown": "hi", "author": {"name": "mod", "isMod": true}, "isInternal": true},
+		"m3": {"id": "m3", "bodyMarkdown": "hey"}
+	},
+	"viewerId": "t2_mod"
+}`
+
+func TestConfig_ModmailConversations(t *testing.T) {
+	m := mock(
+		get("https://oauth.reddit.com/api/mod/conversations?entity=golang%2Crust&limit=2&state=inprogress", modmailListBody),
+		get("https://oauth.reddit.com/api/mod/conversations?after=2&entity=golang%2Crust&limit=2&state=inprogress",
+			`{"conversations": {}, "conversationIds": [], "messages": {}, "viewerId": "t2_mod"}`),
+	)
+	defer m.reset()
+
+	require := require.New(t)
+	c := authedConfig(m)
+	q := &ModmailQuery{Entity: "golang,rust", Limit: 2, State: ModmailInProgress}
+
+	page, err := c.ModmailConversations(nil, q)
+	require.NoError(err)
+	require.Equal("t2_mod", page.ViewerID)
+	require.Len(page.Conversations, 2)
+
+	first, second := page.Conversations[0], page.Conversations[1]
+	require.Equal("first", first.Subject)
+	require.True(first.IsHighlighted)
+	require.Equal(ModmailParticipant{ID: 42, Name: "user", IsOp: true}, first.Participant)
+	require.Len(first.Messages, 2)
+	require.Equal("hello", first.Messages[0].BodyMarkdown)
+	require.True(first.Messages[1].IsInternal)
+
+	require.Equal("golang", second.Owner.DisplayName)
+	require.Equal(time.Date(2019, 4, 3, 20, 31, 22, 431375000, time.UTC), second.LastUpdated.UTC())
+	require.True(second.LastUnread.IsZero())
+	require.Len(second.Messages, 1)
+	require.Equal("2", q.After)
+
+	page, err = c.ModmailConversations(nil, q)
+	require.NoError(err)
+	require.Empty(page.Conversations)
+}
+
+func TestConfig_ModmailActions(t *testing.T) {
+	m := mock(
+		post("https://oauth.reddit.com/api/mod/conversations/1", "body=thanks&isAuthorHidden=true&isInternal=false",
+			`{"conversation": {"id": "1", "objIds": [{"id": "m1", "key": "messages"}]},
+			  "messages": {"m1": {"id": "m1", "bodyMarkdown": "thanks"}}}`),
+		post("https://oauth.reddit.com/api/mod/conversations/1/mute", "num_hours=72", "{}"),
+		post("https://oauth.reddit.com/api/mod/conversations/1/archive", "", "{}"),
+		post("https://oauth.reddit.com/api/mod/conversations/1/highlight", "", "{}"),
+		del("https://oauth.reddit.com/api/mod/conversations/1/highlight", "{}"),
+		post("https://oauth.reddit.com/api/mod/conversations/bulk/read", "entity=golang&state=new",
+			`{"conversation_ids": ["1", "2"]}`),
+	)
+	defer m.reset()
+
+	require := require.New(t)
+	c := authedConfig(m)
+
+	conv, err := c.ReplyModmail(nil, "1", "thanks", ModmailReplyOptions{AuthorHidden: true})
+	require.NoError(err)
+	require.Len(conv.Messages, 1)
+	require.Equal("thanks", conv.Messages[0].BodyMarkdown)
+
+	require.NoError(c.MuteModmail(nil, "1", 72))
+	require.NoError(c.ArchiveModmail(nil, "1"))
+	require.NoError(c.HighlightModmail(nil, "1"))
+	require.NoError(c.UnhighlightModmail(nil, "1"))
+
+	ids, err := c.BulkReadModmail(nil, ModmailNew, "golang")
+	require.NoError(err)
+	require.Equal([]string{"1", "2"}, ids)
+}
@@ -0,0 +1,68 @@
+package reddit
+
+import (
+	"fmt"
+
+	"github.com/google/go-querystring/query"
+)
+
+// ModPage identifies one of the moderation listings of a subreddit.
+type ModPage string
+
+// ModQueue, ModReports, ModSpam, ModEdited and ModUnmoderated are the supported pages for a ModListing.
+const (
+	ModQueue       ModPage = "modqueue"
+	ModReports     ModPage = "reports"
+	ModSpam        ModPage = "spam"
+	ModEdited      ModPage = "edited"
+	ModUnmoderated ModPage = "unmoderated"
+)
+
+// OnlyLinks and OnlyComments restrict a ModListing to a single type of Thing.
+const (
+	OnlyLinks    = "links"
+	OnlyComments = "comments"
+)
+
+// ModListing is a query for a moderation listing of a subreddit. It implements URLer and Lister
+// and can be used with Config.Stream to stream the Links and Comments awaiting moderation.
+// Use "mod" as the SubReddit to list items from all moderated subreddits.
+type ModListing struct {
+	ListingOptions
+	SubReddit string  `url:"-"`
+	Page      ModPage `url:"-"`
+	Only      string  `url:"only,omitempty"` // One of OnlyLinks or OnlyComments. Empty lists both.
+}
+
+// URL returns the URL to use when fetching the moderation listing.
+func (m *ModListing) URL() (string, error) {
+	v, err := query.Values(m)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/r/%s/about/%s.json?%s", RedditAPIURL, m.SubReddit, m.Page, v.Encode()), nil
+}
+
+// List returns the ListingOptions for ModListing
+func (m *ModListing) List() *ListingOptions { return &m.ListingOptions }
+
+// ModLog is a query for the moderation log of a subreddit. It implements URLer and Lister
+// and can be used with Config.Stream to stream ModAction values.
+type ModLog struct {
+	ListingOptions
+	SubReddit string `url:"-"`
+	Type      string `url:"type,omitempty"` // Only list actions of this type, for example removelink.
+	Mod       string `url:"mod,omitempty"`  // Only list actions performed by these comma separated moderators.
+}
+
+// URL returns the URL to use when fetching the moderation log.
+func (m *ModLog) URL() (string, error) {
+	v, err := query.Values(m)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/r/%s/about/log.json?%s", RedditAPIURL, m.SubReddit, v.Encode()), nil
+}
+
+// List returns the ListingOptions for ModLog
+func (m *ModLog) List() *ListingOptions { return &m.ListingOptions }
@@ -0,0 +1,54 @@
+package reddit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const modLogBody = `{
+	"kind": "Listing",
+	"data": {
+		"after": null,
+		"before": null,
+		"children": [
+			{"kind": "modaction", "data": {
+				"action": "removecomment", "mod": "AutoModerator", "target_fullname": "t1_def",
+				"target_author": "spammer", "details": "remove", "created_utc": 1500000000.0,
+				"id": "ModAction_1"
+			}},
+			{"kind": "modaction", "data": {
+				"action": "banuser", "mod": "somemod", "target_author": "spammer",
+				"details": "7 days", "description": "spam", "id": "ModAction_2"
+			}}
+		]
+	}
+}`
+
+func TestConfig_StreamModLog(t *testing.T) {
+	m := mock(get("https://oauth.reddit.com/r/golang/about/log.json?limit=25&mod=AutoModerator%2Csomemod", modLogBody))
+	defer m.reset()
+
+	require := require.New(t)
+	stream := authedConfig(m).Stream(nil, &ModLog{
+		SubReddit: "golang", Mod: "AutoModerator,somemod", ListingOptions: ListingOptions{Limit: 25},
+	})
+
+	var actions []*ModAction
+	for stream.Next() {
+		actions = append(actions, stream.Thing().Data.(*ModAction))
+	}
+	require.NoError(stream.Error())
+	require.Len(actions, 2)
+	require.Equal(ModAction{
+		Action: "removecomment", Mod: "AutoModerator", TargetFullname: "t1_def", TargetAuthor: "spammer",
+		Details: "remove", CreatedUTC: 1500000000, ID: "ModAction_1",
+	}, *actions[0])
+	require.Equal("banuser", actions[1].Action)
+}
+
+func TestModListing_URL(t *testing.T) {
+	u, err := (&ModListing{SubReddit: "mod", Page: ModReports, Only: OnlyComments}).URL()
+	require.NoError(t, err)
+	require.Equal(t, "https://oauth.reddit.com/r/mod/about/reports.json?only=comments", u)
+}
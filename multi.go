@@ -0,0 +1,150 @@
+package reddit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-querystring/query"
+)
+
+// MultiDescription describes the contents of a multireddit when creating or updating it.
+type MultiDescription struct {
+	DisplayName   string           `json:"display_name"`
+	DescriptionMD string           `json:"description_md,omitempty"`
+	KeyColor      string           `json:"key_color,omitempty"`
+	Visibility    string           `json:"visibility,omitempty"` // private, public or hidden
+	Subreddits    []MultiSubReddit `json:"subreddits"`
+}
+
+// multiPath converts the path of a multireddit, as found in LabeledMulti.Path, to the form used by the API.
+func multiPath(path string) string {
+	return strings.Trim(path, "/")
+}
+
+func multiURL(path string) string {
+	return fmt.Sprintf("%s/api/multi/%s", RedditAPIURL, multiPath(path))
+}
+
+// multis fetches a list of multireddits from url.
+func (c *Config) multis(client *http.Client, url string) ([]*LabeledMulti, error) {
+	var things []Thing
+	if err := c.Get(client, url, &things); err != nil {
+		return nil, err
+	}
+	multis := make([]*LabeledMulti, 0, len(things))
+	for _, t := range things {
+		m, ok := t.Data.(*LabeledMulti)
+		if !ok {
+			return nil, fmt.Errorf("unexpected %s in response from %s", t.Kind, url)
+		}
+		multis = append(multis, m)
+	}
+	return multis, nil
+}
+
+// multi performs a request that returns a single multireddit.
+func (c *Config) multi(client *http.Client, method, url string, form url.Values) (*LabeledMulti, error) {
+	var t Thing
+	if err := c.do(client, method, url, form, &t); err != nil {
+		return nil, err
+	}
+	m, ok := t.Data.(*LabeledMulti)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %s response from %s", t.Kind, url)
+	}
+	return m, nil
+}
+
+// MyMultis fetches the multireddits of the authenticated user.
+func (c *Config) MyMultis(client *http.Client) ([]*LabeledMulti, error) {
+	return c.multis(client, RedditAPIURL+"/api/multi/mine")
+}
+
+// UserMultis fetches the public multireddits of user.
+func (c *Config) UserMultis(client *http.Client, user string) ([]*LabeledMulti, error) {
+	return c.multis(client, fmt.Sprintf("%s/api/multi/user/%s", RedditAPIURL, user))
+}
+
+// Multi fetches the multireddit with the provided path, for example /user/spez/m/favorites.
+func (c *Config) Multi(client *http.Client, path string) (*LabeledMulti, error) {
+	return c.multi(client, http.MethodGet, multiURL(path), nil)
+}
+
+func (c *Config) saveMulti(client *http.Client, method, path string, m MultiDescription) (*LabeledMulti, error) {
+	model, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return c.multi(client, method, multiURL(path), url.Values{"model": {string(model)}})
+}
+
+// CreateMulti creates a multireddit at path. It fails if the multireddit already exists.
+func (c *Config) CreateMulti(client *http.Client, path string, m MultiDescription) (*LabeledMulti, error) {
+	return c.saveMulti(client, http.MethodPost, path, m)
+}
+
+// UpdateMulti replaces the description and subreddits of the multireddit at path, creating it if
+// it does not exist.
+func (c *Config) UpdateMulti(client *http.Client, path string, m MultiDescription) (*LabeledMulti, error) {
+	return c.saveMulti(client, http.MethodPut, path, m)
+}
+
+// DeleteMulti deletes the multireddit at path.
+func (c *Config) DeleteMulti(client *http.Client, path string) error {
+	return c.do(client, http.MethodDelete, multiURL(path), nil, nil)
+}
+
+// CopyMulti copies the multireddit at path from to a new multireddit at path to.
+func (c *Config) CopyMulti(client *http.Client, from, to, displayName string) (*LabeledMulti, error) {
+	form := url.Values{"from": {multiPath(from)}, "to": {multiPath(to)}, "display_name": {displayName}}
+	return c.multi(client, http.MethodPost, RedditAPIURL+"/api/multi/copy", form)
+}
+
+// AddMultiSubReddit adds subreddit to the multireddit at path.
+func (c *Config) AddMultiSubReddit(client *http.Client, path, subreddit string) error {
+	model, err := json.Marshal(MultiSubReddit{Name: subreddit})
+	if err != nil {
+		return err
+	}
+	return c.do(client, http.MethodPut, multiURL(path)+"/r/"+subreddit, url.Values{"model": {string(model)}}, nil)
+}
+
+// RemoveMultiSubReddit removes subreddit from the multireddit at path.
+func (c *Config) RemoveMultiSubReddit(client *http.Client, path, subreddit string) error {
+	return c.do(client, http.MethodDelete, multiURL(path)+"/r/"+subreddit, nil, nil)
+}
+
+// MultiListing is a query for the links of a multireddit. It implements URLer and Lister and can be used
+// with Config.Stream to stream the links of a multireddit.
+type MultiListing struct {
+	ListingOptions
+	Path     string      `url:"-"` // Path of the multireddit, for example /user/spez/m/favorites.
+	Sort     LinkSort    `url:"-"` // Defaults to LinksHot.
+	Duration TopDuration `url:"t,omitempty"`
+}
+
+// URL returns the URL to use when fetching the links of a multireddit.
+func (m *MultiListing) URL() (string, error) {
+	v, err := query.Values(m)
+	if err != nil {
+		return "", err
+	}
+	sort := m.Sort
+	if sort == "" {
+		sort = LinksHot
+	}
+	return fmt.Sprintf("%s/%s/%s.json?%s", RedditAPIURL, multiPath(m.Path), sort, v.Encode()), nil
+}
+
+// List returns the ListingOptions for MultiListing
+func (m *MultiListing) List() *ListingOptions { return &m.ListingOptions }
+
+// MultiSubReddit is a subreddit that is part of a multireddit. Data is only populated when the
+// multireddit is fetched with its subreddits expanded.
+type MultiSubReddit struct {
+	Name string     `json:"name"`
+	Data *SubReddit `json:"data,omitempty"`
+}
@@ -0,0 +1,75 @@
+package reddit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const multiBody = `{"kind": "LabeledMulti", "data": {
+	"can_edit": true, "display_name": "Go", "name": "go", "path": "/user/gopher/m/go", "owner": "gopher",
+	"visibility": "private", "subreddits": [{"name": "golang"}, {"name": "golang_jobs"}]
+}}`
+
+func TestConfig_Multi(t *testing.T) {
+	m := mock(
+		get("https://oauth.reddit.com/api/multi/mine", "["+multiBody+"]"),
+		post("https://oauth.reddit.com/api/multi/user/gopher/m/go",
+			"model=%7B%22display_name%22%3A%22Go%22%2C%22visibility%22%3A%22private%22%2C%22subreddits%22%3A%5B%7B%22name%22%3A%22golang%22%7D%5D%7D",
+			multiBody),
+		put("https://oauth.reddit.com/api/multi/user/gopher/m/go/r/golang_jobs", "model=%7B%22name%22%3A%22golang_jobs%22%7D", "{}"),
+		get("https://oauth.reddit.com/api/multi/user/gopher/m/go", multiBody),
+		put("https://oauth.reddit.com/api/multi/user/gopher/m/go",
+			"model=%7B%22display_name%22%3A%22Go%22%2C%22visibility%22%3A%22private%22%2C%22subreddits%22%3A%5B%7B%22name%22%3A%22golang%22%7D%5D%7D",
+			multiBody),
+		del("https://oauth.reddit.com/api/multi/user/gopher/m/go/r/golang_jobs", "{}"),
+		del("https://oauth.reddit.com/api/multi/user/gopher/m/go", "{}"),
+	)
+	defer m.reset()
+
+	require := require.New(t)
+	c := authedConfig(m)
+
+	multis, err := c.MyMultis(nil)
+	require.NoError(err)
+	require.Len(multis, 1)
+	require.Equal("/user/gopher/m/go", multis[0].Path)
+
+	multi, err := c.CreateMulti(nil, "/user/gopher/m/go/", MultiDescription{
+		DisplayName: "Go", Visibility: "private", Subreddits: []MultiSubReddit{{Name: "golang"}},
+	})
+	require.NoError(err)
+	require.Equal("Go", multi.DisplayName)
+
+	require.NoError(c.AddMultiSubReddit(nil, multi.Path, "golang_jobs"))
+
+	multi, err = c.Multi(nil, multi.Path)
+	require.NoError(err)
+	require.Equal([]MultiSubReddit{{Name: "golang"}, {Name: "golang_jobs"}}, multi.Subreddits)
+
+	_, err = c.UpdateMulti(nil, multi.Path, MultiDescription{
+		DisplayName: "Go", Visibility: "private", Subreddits: []MultiSubReddit{{Name: "golang"}},
+	})
+	require.NoError(err)
+	require.NoError(c.RemoveMultiSubReddit(nil, multi.Path, "golang_jobs"))
+	require.NoError(c.DeleteMulti(nil, multi.Path))
+}
+
+func TestConfig_StreamMulti(t *testing.T) {
+	m := mock(
+		get("https://oauth.reddit.com/user/gopher/m/go/top.json?limit=5&t=week", topPostsBody(0, 5)),
+		get("https://oauth.reddit.com/user/gopher/m/go/top.json?after=4&count=5&limit=5&t=week", topPostsBody(5, 1)),
+	)
+	defer m.reset()
+
+	require := require.New(t)
+	stream := authedConfig(m).Stream(nil, &MultiListing{
+		Path: "/user/gopher/m/go", Sort: LinksTop, Duration: TopWeek, ListingOptions: ListingOptions{Limit: 5},
+	})
+	ctr := 0
+	for ; stream.Next(); ctr++ {
+		require.IsType(&Link{}, stream.Thing().Data)
+	}
+	require.NoError(stream.Error())
+	require.Equal(6, ctr)
+}
@@ -0,0 +1,156 @@
+package reddit
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/jonboulle/clockwork"
+)
+
+// Option changes how a Config makes requests. Options are applied with Config.Configure. A Config
+// without options uses reddit's servers, the http.Client passed to each method, the system clock,
+// the user agent in its Credentials and a Limiter shared by all such Configs.
+type Option func(*settings)
+
+// settings holds the options of a Config. The zero value uses the package defaults.
+type settings struct {
+	apiURL     string
+	authURL    string
+	webURL     string
+	doer       doer
+	clock      clockwork.Clock
+	userAgent  string
+	limiter    Limiter
+	middleware []Middleware
+	decode     DecodeOptions
+}
+
+// WithAPIURL makes API calls go to baseURL instead of RedditAPIURL. URLs passed to Get and Post, and those
+// returned by URLers, that start with RedditAPIURL are rewritten to start with baseURL.
+func WithAPIURL(baseURL string) Option {
+	return func(s *settings) { s.apiURL = strings.TrimSuffix(baseURL, "/") }
+}
+
+// WithAuthURL makes AuthScript obtain tokens from authURL instead of RedditAuthURL.
+func WithAuthURL(authURL string) Option {
+	return func(s *settings) { s.authURL = authURL }
+}
+
+// WithWebURL makes share links resolve against baseURL instead of RedditWebURL.
+func WithWebURL(baseURL string) Option {
+	return func(s *settings) { s.webURL = strings.TrimSuffix(baseURL, "/") }
+}
+
+// Doer sends an HTTP request and returns its response. *http.Client implements Doer.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type customDoer struct{ d Doer }
+
+func (c customDoer) do(req *http.Request, _ *http.Client) (*http.Response, error) { return c.d.Do(req) }
+
+// WithDoer sends all requests with d, ignoring the http.Client passed to each method. Share links are
+// only resolved if d does not follow redirects.
+func WithDoer(d Doer) Option {
+	return func(s *settings) { s.doer = customDoer{d} }
+}
+
+type transportDoer struct{ rt http.RoundTripper }
+
+func (t transportDoer) do(req *http.Request, client *http.Client) (*http.Response, error) {
+	var c http.Client
+	if client != nil {
+		c = *client
+	}
+	c.Transport = t.rt
+	return c.Do(req)
+}
+
+// WithTransport sends all requests with rt in place of the Transport of the http.Client passed to each method.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(s *settings) { s.doer = transportDoer{rt} }
+}
+
+// WithClock uses clock to check token expiry and to wait for rate limit periods to end. Unless WithLimiter
+// is also given, the Config gets its own Limiter using clock.
+func WithClock(clock clockwork.Clock) Option {
+	return func(s *settings) { s.clock = clock }
+}
+
+// WithUserAgent sends userAgent in place of the user agent in the Credentials of the Config.
+func WithUserAgent(userAgent string) Option {
+	return func(s *settings) { s.userAgent = userAgent }
+}
+
+// WithLimiter paces the requests of the Config with l instead of the shared Limiter.
+func WithLimiter(l Limiter) Option {
+	return func(s *settings) { s.limiter = l }
+}
+
+// WithDecodeOptions decodes the responses to requests made by the Config with o instead of the zero
+// DecodeOptions.
+func WithDecodeOptions(o DecodeOptions) Option {
+	return func(s *settings) { s.decode = o }
+}
+
+// Configure applies opts to c and returns c for chaining. Options are not saved by Config.Save.
+func (c *Config) Configure(opts ...Option) *Config {
+	for _, opt := range opts {
+		opt(&c.settings)
+	}
+	if c.settings.clock != nil && c.settings.limiter == nil {
+		c.settings.limiter = &rateLimiter{clock: c.settings.clock}
+	}
+	return c
+}
+
+// apiURL rewrites u to use the configured API base URL.
+func (c *Config) apiURL(u string) string {
+	if c.settings.apiURL == "" || !strings.HasPrefix(u, RedditAPIURL) {
+		return u
+	}
+	return c.settings.apiURL + strings.TrimPrefix(u, RedditAPIURL)
+}
+
+func (c *Config) authURL() string {
+	if c.settings.authURL == "" {
+		return RedditAuthURL
+	}
+	return c.settings.authURL
+}
+
+func (c *Config) webURL() string {
+	if c.settings.webURL == "" {
+		return RedditWebURL
+	}
+	return c.settings.webURL
+}
+
+func (c *Config) doer() doer {
+	if c.settings.doer == nil {
+		return defaultDoer
+	}
+	return c.settings.doer
+}
+
+func (c *Config) clock() clockwork.Clock {
+	if c.settings.clock == nil {
+		return clock
+	}
+	return c.settings.clock
+}
+
+func (c *Config) userAgent() string {
+	if c.settings.userAgent == "" {
+		return c.Credentials.UserAgent
+	}
+	return c.settings.userAgent
+}
+
+func (c *Config) limiter() Limiter {
+	if c.settings.limiter == nil {
+		return defaultLimiter
+	}
+	return c.settings.limiter
+}
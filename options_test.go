@@ -0,0 +1,91 @@
+package reddit
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) { return f(req) }
+
+type countingLimiter struct{ waits, updates int }
+
+func (l *countingLimiter) Wait()            { l.waits++ }
+func (l *countingLimiter) Update(RateLimit) { l.updates++ }
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestConfig_Configure(t *testing.T) {
+	require := require.New(t)
+
+	c := &Config{}
+	require.Equal(RedditAPIURL+"/r/golang/new.json", c.apiURL(RedditAPIURL+"/r/golang/new.json"))
+	require.Equal(RedditAuthURL, c.authURL())
+	require.Equal(RedditWebURL, c.webURL())
+	require.Equal(defaultLimiter, c.limiter())
+
+	c.Configure(WithAPIURL("http://localhost:1234/"), WithAuthURL("http://localhost:1234/token"), WithWebURL("http://localhost:5678"))
+	require.Equal("http://localhost:1234/r/golang/new.json", c.apiURL(RedditAPIURL+"/r/golang/new.json"))
+	require.Equal("https://example.com/a", c.apiURL("https://example.com/a"))
+	require.Equal("http://localhost:1234/token", c.authURL())
+	require.Equal("http://localhost:5678", c.webURL())
+}
+
+func TestConfig_Options(t *testing.T) {
+	require := require.New(t)
+
+	var requests []string
+	respond := func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.Method+" "+req.URL.String()+" "+req.Header.Get("User-Agent"))
+		h := http.Header{}
+		if strings.HasSuffix(req.URL.Path, "/token") {
+			return &http.Response{StatusCode: http.StatusOK, Header: h, Body: ioutil.NopCloser(strings.NewReader(testTokenResponse))}, nil
+		}
+		h.Set("X-Ratelimit-Used", "1")
+		h.Set("X-Ratelimit-Remaining", "0")
+		h.Set("X-Ratelimit-Reset", "60")
+		body := `{"kind": "Listing", "data": {"children": []}}`
+		return &http.Response{StatusCode: http.StatusOK, Header: h, Body: ioutil.NopCloser(strings.NewReader(body))}, nil
+	}
+	now := time.Unix(1500000000, 0)
+	fake := clockwork.NewFakeClockAt(now)
+	c := (&Config{Credentials: testConfig.Credentials}).Configure(
+		WithAPIURL("http://api"), WithAuthURL("http://auth/token"), WithDoer(doerFunc(respond)),
+		WithClock(fake), WithUserAgent("custom"),
+	)
+	require.NoError(c.AuthScript(nil))
+	require.Equal(now.Add(time.Hour).Unix(), c.AuthToken.Expires)
+	require.NoError(c.Get(nil, RedditAPIURL+"/r/golang/new.json", nil))
+	require.Equal([]string{"POST http://auth/token custom", "GET http://api/r/golang/new.json custom"}, requests)
+
+	// The rate limit is used up, so the next request waits for the fake clock.
+	done := make(chan error)
+	go func() { done <- c.Get(nil, RedditAPIURL+"/r/golang/new.json", nil) }()
+	fake.BlockUntil(1)
+	fake.Advance(time.Minute)
+	require.NoError(<-done)
+	require.Len(requests, 3)
+
+	// A transport replaces the Transport of the client passed in.
+	requests = nil
+	limiter := &countingLimiter{}
+	c = (&Config{AuthToken: AuthToken{Token: "t", Type: "bearer"}}).Configure(
+		WithTransport(roundTripperFunc(respond)), WithLimiter(limiter), WithUserAgent("transport"),
+	)
+	require.NoError(c.Get(http.DefaultClient, RedditAPIURL+"/r/golang/new.json", nil))
+	require.NoError(c.Get(nil, RedditAPIURL+"/r/golang/new.json", nil))
+	require.Equal([]string{
+		"GET https://oauth.reddit.com/r/golang/new.json transport",
+		"GET https://oauth.reddit.com/r/golang/new.json transport",
+	}, requests)
+	require.Equal(&countingLimiter{waits: 2, updates: 2}, limiter)
+}
@@ -0,0 +1,256 @@
+package reddit
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Target is the reddit entity a URL refers to. Only the fields relevant to the URL are set. For example a
+// permalink to a comment sets SubReddit, Link, Comment and possibly Context.
+type Target struct {
+	SubReddit string   // Name of the subreddit, without the r/ prefix.
+	Sort      LinkSort // Sort order of a subreddit listing, for example from /r/golang/new.
+	Link      Fullname
+	Comment   Fullname
+	Context   int    // Number of parents of Comment to show, from the context query parameter.
+	User      string // Name of the user, without the u/ prefix.
+	Multi     string // Path of a multireddit, for example /user/spez/m/favorites.
+	WikiPage  string // Name of a wiki page of SubReddit.
+	ShareCode string // Code of a share link. Use Config.ResolveShareLink to find what it refers to.
+}
+
+// redditHost returns true if host serves reddit pages. This includes subdomains like old., np. and language
+// specific subdomains.
+func redditHost(host string) bool {
+	host = strings.ToLower(host)
+	return host == "reddit.com" || strings.HasSuffix(host, ".reddit.com")
+}
+
+// ParseURL parses a link to reddit into a Target. It accepts links with or without a scheme from reddit.com
+// and its subdomains (www., old., new., np., etc) as well as redd.it short links. Paths like /r/golang and
+// /u/spez without a host are also accepted.
+//
+// Supported paths are subreddits (/r/golang, /r/golang/new), links and comments (/r/golang/comments/abc/title,
+// /r/golang/comments/abc/title/def, /comments/abc, /gallery/abc), users (/u/spez, /user/spez/submitted),
+// multireddits (/user/spez/m/favorites), wiki pages (/r/golang/wiki/page) and share links (/r/golang/s/code).
+func ParseURL(raw string) (*Target, error) {
+	s := strings.TrimSpace(raw)
+	if !strings.Contains(s, "://") {
+		if first := strings.SplitN(strings.TrimPrefix(s, "/"), "/", 2)[0]; strings.Contains(first, ".") {
+			s = "https://" + s
+		} else {
+			s = "/" + strings.TrimPrefix(s, "/")
+		}
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reddit url %q: %v", raw, err)
+	}
+	var parts []string
+	for _, p := range strings.Split(u.Path, "/") {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+
+	t := &Target{}
+	if ctx := u.Query().Get("context"); ctx != "" {
+		if t.Context, err = strconv.Atoi(ctx); err != nil {
+			return nil, fmt.Errorf("invalid context %q in %q", ctx, raw)
+		}
+	}
+
+	if err := t.parse(strings.ToLower(u.Hostname()), parts, raw); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// parse populates t from the host and the non empty path elements of a URL.
+func (t *Target) parse(host string, parts []string, raw string) error {
+	switch {
+	case host == "redd.it" || host == "www.redd.it":
+		if len(parts) != 1 {
+			return fmt.Errorf("invalid short link %q", raw)
+		}
+		return t.setLink(parts[0], "")
+	case host != "" && !redditHost(host):
+		return fmt.Errorf("%q is not a reddit url", raw)
+	case len(parts) == 0:
+		return fmt.Errorf("no reddit path present in %q", raw)
+	}
+
+	switch strings.ToLower(parts[0]) {
+	case "r":
+		if len(parts) < 2 {
+			return fmt.Errorf("no subreddit present in %q", raw)
+		}
+		t.SubReddit = parts[1]
+		return t.parseSubReddit(parts[2:], raw)
+	case "u", "user":
+		if len(parts) < 2 {
+			return fmt.Errorf("no user present in %q", raw)
+		}
+		t.User = parts[1]
+		rest := parts[2:]
+		if len(rest) >= 2 && rest[0] == "m" {
+			t.User, t.Multi = "", "/user/"+parts[1]+"/m/"+rest[1]
+			return nil
+		}
+		if len(rest) >= 2 && rest[0] == "comments" {
+			return t.parseComments(rest[1:], raw)
+		}
+		return nil
+	case "comments", "gallery":
+		return t.parseComments(parts[1:], raw)
+	}
+	return fmt.Errorf("unsupported reddit url %q", raw)
+}
+
+func (t *Target) setLink(id, comment string) error {
+	link, err := ParseFullname(string(KindLink) + "_" + strings.ToLower(id))
+	if err != nil {
+		return err
+	}
+	t.Link = link
+	if comment == "" {
+		return nil
+	}
+	if t.Comment, err = ParseFullname(string(KindComment) + "_" + strings.ToLower(comment)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// parseComments parses the remainder of a comments path: link id, optional title slug and optional comment id.
+func (t *Target) parseComments(parts []string, raw string) error {
+	if len(parts) == 0 {
+		return fmt.Errorf("no link id present in %q", raw)
+	}
+	comment := ""
+	if len(parts) >= 3 {
+		comment = parts[2]
+	}
+	return t.setLink(parts[0], comment)
+}
+
+// parseSubReddit parses the part of a path following /r/{subreddit}.
+func (t *Target) parseSubReddit(parts []string, raw string) error {
+	if len(parts) == 0 {
+		return nil
+	}
+	switch parts[0] {
+	case "comments":
+		return t.parseComments(parts[1:], raw)
+	case "wiki":
+		t.WikiPage = strings.Join(parts[1:], "/")
+		if t.WikiPage == "" {
+			t.WikiPage = "index"
+		}
+		return nil
+	case "s":
+		if len(parts) < 2 {
+			return fmt.Errorf("no share code present in %q", raw)
+		}
+		t.ShareCode = parts[1]
+		return nil
+	case string(LinksHot), string(LinksNew), string(LinksRising), string(LinksTop), string(LinksControversial):
+		t.Sort = LinkSort(parts[0])
+		return nil
+	case "about", "search", "submit":
+		return nil
+	}
+	return fmt.Errorf("unsupported subreddit url %q", raw)
+}
+
+// Lister returns a Lister for the listing the Target refers to. Subreddits are listed using SubRedditPosts,
+// users using UserListing and multireddits using MultiListing. An error is returned for other targets.
+func (t *Target) Lister() (Lister, error) {
+	switch {
+	case t.ShareCode != "":
+		return nil, fmt.Errorf("share link must be resolved before listing")
+	case t.Multi != "":
+		return &MultiListing{Path: t.Multi}, nil
+	case t.Link != "" || t.WikiPage != "":
+		return nil, fmt.Errorf("target is not a listing")
+	case t.User != "":
+		return &UserListing{User: t.User}, nil
+	case t.SubReddit != "":
+		return &SubRedditPosts{SubReddit: t.SubReddit, Sort: t.Sort}, nil
+	}
+	return nil, fmt.Errorf("empty target")
+}
+
+// CommentsPage is a Link along with its comments, as returned by Config.Fetch.
+type CommentsPage struct {
+	Link     *Link
+	Comments []Thing
+}
+
+// Fetch fetches the entity t refers to. It returns a *CommentsPage for links and comments, a *WikiPage for
+// wiki pages, a *LabeledMulti for multireddits, an *Account for users and a *SubReddit for subreddits.
+// Share links are resolved before fetching.
+func (c *Config) Fetch(client *http.Client, t *Target) (interface{}, error) {
+	if t.ShareCode != "" {
+		resolved, err := c.ResolveShareLink(client, t)
+		if err != nil {
+			return nil, err
+		}
+		t = resolved
+	}
+	switch {
+	case t.Link != "":
+		link, comments, err := c.Comments(client, &Comments{Link: t.Link, Comment: t.Comment, Context: t.Context})
+		if err != nil {
+			return nil, err
+		}
+		return &CommentsPage{Link: link, Comments: comments}, nil
+	case t.WikiPage != "":
+		return c.WikiPage(client, t.SubReddit, t.WikiPage, "")
+	case t.Multi != "":
+		return c.Multi(client, t.Multi)
+	case t.User != "":
+		return c.Account(client, t.User)
+	case t.SubReddit != "":
+		return c.SubReddit(client, t.SubReddit)
+	}
+	return nil, fmt.Errorf("empty target")
+}
+
+// RedditWebURL is the base URL of the reddit website, used to resolve share links.
+const RedditWebURL = "https://www.reddit.com"
+
+// ResolveShareLink resolves a share link, like https://www.reddit.com/r/golang/s/abc, to the Target it
+// redirects to.
+func (c *Config) ResolveShareLink(client *http.Client, t *Target) (*Target, error) {
+	if t.ShareCode == "" {
+		return t, nil
+	}
+	u := fmt.Sprintf("%s/r/%s/s/%s", c.webURL(), t.SubReddit, t.ShareCode)
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %v", u, err)
+	}
+	req.Header.Add("User-Agent", c.userAgent())
+
+	noRedirect := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	if client != nil {
+		copied := *client
+		copied.CheckRedirect = noRedirect.CheckRedirect
+		noRedirect = &copied
+	}
+	var location string
+	_, err = c.httpRequest(noRedirect, RequestInfo{Request: req}, func(info *RequestInfo) error {
+		if location = info.Response.Header.Get("Location"); location == "" {
+			return fmt.Errorf("share link %s did not redirect (http status %d)", u, info.Response.StatusCode)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ParseURL(location)
+}
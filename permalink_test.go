@@ -0,0 +1,93 @@
+package reddit
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseURL(t *testing.T) {
+	tests := []struct {
+		url    string
+		target Target
+	}{
+		{"https://www.reddit.com/r/golang/comments/abc123/some_title/def456/?context=3",
+			Target{SubReddit: "golang", Link: "t3_abc123", Comment: "t1_def456", Context: 3}},
+		{"old.reddit.com/r/golang/comments/abc123/some_title/", Target{SubReddit: "golang", Link: "t3_abc123"}},
+		{"https://np.reddit.com/r/golang/comments/abc123", Target{SubReddit: "golang", Link: "t3_abc123"}},
+		{"http://redd.it/abc123", Target{Link: "t3_abc123"}},
+		{"https://www.reddit.com/comments/ABC123", Target{Link: "t3_abc123"}},
+		{"https://www.reddit.com/gallery/abc123", Target{Link: "t3_abc123"}},
+		{"https://de.reddit.com/r/golang/new/", Target{SubReddit: "golang", Sort: LinksNew}},
+		{"/r/golang", Target{SubReddit: "golang"}},
+		{"r/golang/about", Target{SubReddit: "golang"}},
+		{"/u/spez", Target{User: "spez"}},
+		{"https://reddit.com/user/spez/submitted", Target{User: "spez"}},
+		{"https://www.reddit.com/user/spez/comments/abc123/title", Target{User: "spez", Link: "t3_abc123"}},
+		{"https://www.reddit.com/user/spez/m/favorites", Target{Multi: "/user/spez/m/favorites"}},
+		{"https://www.reddit.com/r/golang/wiki/config/sidebar", Target{SubReddit: "golang", WikiPage: "config/sidebar"}},
+		{"https://www.reddit.com/r/golang/wiki/", Target{SubReddit: "golang", WikiPage: "index"}},
+		{"https://www.reddit.com/r/golang/s/AbCdEf", Target{SubReddit: "golang", ShareCode: "AbCdEf"}},
+	}
+	for _, tc := range tests {
+		target, err := ParseURL(tc.url)
+		require.NoError(t, err, tc.url)
+		require.Equal(t, tc.target, *target, tc.url)
+	}
+
+	for _, invalid := range []string{"https://golang.org/r/golang", "https://redd.it/", "/r/", "/wiki", "https://reddit.com/r/golang/comments/"} {
+		_, err := ParseURL(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestTarget_Lister(t *testing.T) {
+	target, err := ParseURL("https://old.reddit.com/r/golang/top")
+	require.NoError(t, err)
+	l, err := target.Lister()
+	require.NoError(t, err)
+	require.Equal(t, &SubRedditPosts{SubReddit: "golang", Sort: LinksTop}, l)
+
+	target, err = ParseURL("/u/spez")
+	require.NoError(t, err)
+	l, err = target.Lister()
+	require.NoError(t, err)
+	u, err := l.URL()
+	require.NoError(t, err)
+	require.Equal(t, "https://oauth.reddit.com/user/spez/overview.json?", u)
+
+	target, err = ParseURL("https://redd.it/abc")
+	require.NoError(t, err)
+	_, err = target.Lister()
+	require.Error(t, err)
+}
+
+func TestConfig_FetchShareLink(t *testing.T) {
+	share := response{
+		statusCode:      http.StatusMovedPermanently,
+		requestURL:      "https://www.reddit.com/r/golang/s/AbCdEf",
+		headers:         map[string]string{"User-Agent": "useragent"},
+		responseHeaders: map[string]string{"Location": "https://www.reddit.com/r/golang/comments/abc/title/def/"},
+	}
+	m := mock(share, get("https://oauth.reddit.com/comments/abc.json?comment=def", `[
+		{"kind": "Listing", "data": {"children": [{"kind": "t3", "data": {"id": "abc", "title": "Go 2"}}]}},
+		{"kind": "Listing", "data": {"children": [
+			{"kind": "t1", "data": {"id": "def", "body": "Finally"}},
+			{"kind": "more", "data": {"id": "ghi", "name": "t1_ghi", "parent_id": "t3_abc", "count": 2, "children": ["ghi", "jkl"]}}
+		]}}
+	]`))
+	defer m.reset()
+
+	require := require.New(t)
+	target, err := ParseURL("https://www.reddit.com/r/golang/s/AbCdEf")
+	require.NoError(err)
+
+	v, err := authedConfig(m).Fetch(nil, target)
+	require.NoError(err)
+	page := v.(*CommentsPage)
+	require.Equal("Go 2", page.Link.Title)
+	require.Len(page.Comments, 2)
+	require.Equal("Finally", page.Comments[0].Data.(*Comment).Body)
+	require.Equal([]string{"ghi", "jkl"}, page.Comments[1].Data.(*More).Children)
+}
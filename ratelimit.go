@@ -0,0 +1,101 @@
+package reddit
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+)
+
+// RateLimit is the rate limit state reported by reddit in the headers of an API response.
+//
+// See https://github.com/reddit/reddit/wiki/API#rules
+type RateLimit struct {
+	Used      int       // Requests made in the current period.
+	Remaining float64   // Requests remaining in the current period.
+	Reset     time.Time // Time at which the current period ends.
+}
+
+// parseRateLimit parses the X-Ratelimit-* headers of a response. It returns false if the headers are absent
+// or invalid.
+func parseRateLimit(h http.Header, now time.Time) (RateLimit, bool) {
+	used, err := strconv.Atoi(h.Get("X-Ratelimit-Used"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	remaining, err := strconv.ParseFloat(h.Get("X-Ratelimit-Remaining"), 64)
+	if err != nil {
+		return RateLimit{}, false
+	}
+	reset, err := strconv.Atoi(h.Get("X-Ratelimit-Reset"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	return RateLimit{Used: used, Remaining: remaining, Reset: now.Add(time.Duration(reset) * time.Second)}, true
+}
+
+// Limiter paces the requests made to reddit.
+type Limiter interface {
+	// Wait blocks until a request may be made.
+	Wait()
+	// Update records the rate limit state reported by reddit in a response.
+	Update(RateLimit)
+}
+
+// NewLimiter returns a Limiter that follows the rate limit reported by reddit. Once the requests remaining in
+// the current period are used up, Wait blocks until the period ends. Requests are not limited until the
+// first rate limit state has been received.
+func NewLimiter() Limiter {
+	return &rateLimiter{}
+}
+
+type rateLimiter struct {
+	clock     clockwork.Clock // The package clock is used if nil.
+	mu        sync.Mutex
+	known     bool
+	remaining float64
+	reset     time.Time
+}
+
+func (l *rateLimiter) Wait() {
+	clk := l.clock
+	if clk == nil {
+		clk = clock
+	}
+	for {
+		l.mu.Lock()
+		now := clk.Now()
+		if l.known && !now.Before(l.reset) {
+			l.known = false
+		}
+		if !l.known || l.remaining >= 1 {
+			if l.known {
+				l.remaining--
+			}
+			l.mu.Unlock()
+			return
+		}
+		wait := l.reset.Sub(now)
+		l.mu.Unlock()
+		clk.Sleep(wait)
+	}
+}
+
+func (l *rateLimiter) Update(r RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// Responses to concurrent requests may arrive out of order. Within a period, the lowest remaining
+	// count is the most recent one.
+	samePeriod := l.known && r.Reset.Sub(l.reset) < time.Second && l.reset.Sub(r.Reset) < time.Second
+	if samePeriod && r.Remaining > l.remaining {
+		return
+	}
+	l.known, l.remaining, l.reset = true, r.Remaining, r.Reset
+}
+
+var defaultLimiter = NewLimiter()
+
+// maxRetries is the number of times a request that is rejected for exceeding the rate limit is retried.
+const maxRetries = 3
@@ -0,0 +1,51 @@
+package reddit
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Now()
+	h := http.Header{}
+	_, ok := parseRateLimit(h, now)
+	require.False(t, ok)
+
+	h.Set("X-Ratelimit-Used", "12")
+	h.Set("X-Ratelimit-Remaining", "588.0")
+	h.Set("X-Ratelimit-Reset", "30")
+	limit, ok := parseRateLimit(h, now)
+	require.True(t, ok)
+	require.Equal(t, RateLimit{Used: 12, Remaining: 588, Reset: now.Add(30 * time.Second)}, limit)
+}
+
+func TestConfig_RetryWhenRateLimited(t *testing.T) {
+	limited := get("https://oauth.reddit.com/r/golang/about.json", "Too Many Requests")
+	limited.statusCode = http.StatusTooManyRequests
+	limited.responseHeaders = map[string]string{
+		"X-Ratelimit-Used": "600", "X-Ratelimit-Remaining": "0", "X-Ratelimit-Reset": "5",
+	}
+	m := mock(limited, get("https://oauth.reddit.com/r/golang/about.json", `{"kind": "t5", "data": {"display_name": "golang"}}`))
+	defer m.reset()
+
+	c := authedConfig(m)
+	done := make(chan error)
+	go func() {
+		_, err := c.SubReddit(nil, "golang")
+		done <- err
+	}()
+
+	fake := clock.(*clockwork.FakeClock)
+	fake.BlockUntil(1)
+	select {
+	case err := <-done:
+		t.Fatalf("request completed before the rate limit reset: %v", err)
+	default:
+	}
+	fake.Advance(5 * time.Second)
+	require.NoError(t, <-done)
+}
@@ -1,9 +1,9 @@
 package reddit
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -11,21 +11,7 @@ import (
 // Get performs an authentication GET request to the provided URL using the provided http.Client instance.
 // Responses are unmarshalled into val.
 func (c *Config) Get(client *http.Client, url string, val interface{}) error {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request for %s: %v", url, err)
-	}
-	req.Header.Add("User-Agent", c.Credentials.UserAgent)
-	req.Header.Add("Authorization", fmt.Sprintf("%s %s", c.AuthToken.Type, c.AuthToken.Token))
-
-	data, err := httpRequest(req, client)
-	if err != nil {
-		return err
-	}
-	if err := json.Unmarshal(data, val); err != nil {
-		return fmt.Errorf("failed to parse response from %s: %v", url, err)
-	}
-	return nil
+	return c.do(client, http.MethodGet, url, nil, val)
 }
 
 // Stream represents a stream of Thing values obtained from a Listing url.
@@ -36,17 +22,41 @@ type Stream struct {
 	listing Listing
 	index   int
 	err     error
+	since   time.Time
+	done    bool
+	page    int
 }
 
 // Error returns a non-nil error if there were any errors when fetching the listing.
 func (s *Stream) Error() error { return s.err }
 
+// Page returns the number of Listing pages requested so far.
+func (s *Stream) Page() int { return s.page }
+
 func (s *Stream) indexValid() bool { return s.index >= 0 && s.index < len(s.listing.Children) }
 
+// Since stops the stream at the first Thing created before t. It is meant for listings sorted
+// newest first, such as /new or the moderation log. It returns s for chaining.
+func (s *Stream) Since(t time.Time) *Stream {
+	s.since = t
+	return s
+}
+
 // Next returns true iff there are more Things to read. It automatically fetches a new Listing when
 // the current one is exhausted. Always call Error() after Next returns false to check if any errors
 // are present.
 func (s *Stream) Next() bool {
+	if s.done || !s.next() {
+		return false
+	}
+	if created, ok := CreatedAt(s.Thing()); ok && !s.since.IsZero() && created.Before(s.since) {
+		s.done = true
+		return false
+	}
+	return true
+}
+
+func (s *Stream) next() bool {
 	if s.err != nil {
 		return false
 	}
@@ -67,7 +77,8 @@ func (s *Stream) Next() bool {
 		return false
 	}
 	var t Thing
-	s.index, s.err = 0, s.c.Get(s.client, url, &t)
+	s.page++
+	s.index, s.err = 0, s.c.request(s.client, http.MethodGet, url, nil, &t, RequestInfo{StreamPage: s.page})
 	if s.err != nil {
 		return false
 	}
@@ -80,7 +91,7 @@ func (s *Stream) Next() bool {
 // stream. This will return the zero value for Thing if Stream.Error() is non-nil or
 // the end of the stream has been reached.
 func (s *Stream) Thing() Thing {
-	if s.err == nil && s.indexValid() {
+	if s.err == nil && !s.done && s.indexValid() {
 		return s.listing.Children[s.index]
 	}
 	return Thing{}
@@ -106,6 +117,18 @@ const (
 	TopAll   TopDuration = "all"
 )
 
+// LinkSort represents a sort order for a listing of links.
+type LinkSort string
+
+// LinksHot, LinksNew, LinksRising, LinksTop and LinksControversial are supported sort values for listings of links.
+const (
+	LinksHot           LinkSort = "hot"
+	LinksNew           LinkSort = "new"
+	LinksRising        LinkSort = "rising"
+	LinksTop           LinkSort = "top"
+	LinksControversial LinkSort = "controversial"
+)
+
 // ListingOptions control the size and position of a streamed listing.
 // See https://www.reddit.com/dev/api for more information on what these
 // parameters mean.
@@ -147,3 +170,28 @@ func (t *TopPosts) URL() (string, error) {
 
 // List returns the ListingOptions for TopPosts
 func (t *TopPosts) List() *ListingOptions { return &t.ListingOptions }
+
+// SubRedditPosts is a query for the links of a subreddit. It implements URLer and Lister and can be used
+// with Config.Stream to stream the links of a subreddit in any sort order.
+type SubRedditPosts struct {
+	ListingOptions
+	SubReddit string      `url:"-"`
+	Sort      LinkSort    `url:"-"` // Defaults to LinksHot.
+	Duration  TopDuration `url:"t,omitempty"`
+}
+
+// URL returns the URL to use when fetching the links of a subreddit.
+func (s *SubRedditPosts) URL() (string, error) {
+	v, err := query.Values(s)
+	if err != nil {
+		return "", err
+	}
+	sort := s.Sort
+	if sort == "" {
+		sort = LinksHot
+	}
+	return fmt.Sprintf("%s/r/%s/%s.json?%s", RedditAPIURL, s.SubReddit, sort, v.Encode()), nil
+}
+
+// List returns the ListingOptions for SubRedditPosts
+func (s *SubRedditPosts) List() *ListingOptions { return &s.ListingOptions }
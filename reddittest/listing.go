@@ -0,0 +1,242 @@
+package reddittest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	reddit "github.com/sridharv/reddit-go"
+)
+
+// Default and maximum number of Things in a page of a listing.
+const (
+	defaultLimit = 25
+	maxLimit     = 100
+)
+
+// Link returns a Thing holding a Link with the provided id and title.
+func Link(id, title string) reddit.Thing {
+	return reddit.Thing{Kind: string(reddit.KindLink), Data: &reddit.Link{ID: id, Name: reddit.Fullname("t3_" + id), Title: title}}
+}
+
+// Comment returns a Thing holding a Comment with the provided id, body and replies.
+func Comment(id, body string, replies ...reddit.Thing) reddit.Thing {
+	return reddit.Thing{Kind: string(reddit.KindComment), Data: &reddit.Comment{
+		ID: id, Name: reddit.Fullname("t1_" + id), Body: body, Replies: replies,
+	}}
+}
+
+// thing is the JSON form of a Thing sent by reddit.
+type thing struct {
+	Kind string      `json:"kind"`
+	Data interface{} `json:"data"`
+}
+
+// fullname returns the fullname of t, falling back to the name or id in its data.
+func fullname(t reddit.Thing) string {
+	if t.Name != "" {
+		return string(t.Name)
+	}
+	var d struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	if b, err := json.Marshal(t.Data); err == nil {
+		json.Unmarshal(b, &d)
+	}
+	if d.Name != "" {
+		return d.Name
+	}
+	if d.ID == "" {
+		d.ID = t.ID
+	}
+	return t.Kind + "_" + d.ID
+}
+
+func listingThing(children []reddit.Thing, before, after string) thing {
+	things := make([]thing, len(children))
+	for i, c := range children {
+		things[i] = thing{Kind: c.Kind, Data: c.Data}
+	}
+	return thing{Kind: "Listing", Data: map[string]interface{}{
+		"after": nullable(after), "before": nullable(before), "dist": len(children), "modhash": "", "children": things,
+	}}
+}
+
+func nullable(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func intParam(q url.Values, key string) int {
+	n, _ := strconv.Atoi(q.Get(key))
+	return n
+}
+
+func index(things []reddit.Thing, name string) int {
+	for i, t := range things {
+		if fullname(t) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// listing returns the page of things requested by r. Like reddit, the link back to the previous page
+// (before when paging with after, after when paging with before) is only set if count is non-zero.
+func listing(things []reddit.Thing, r *http.Request) thing {
+	q := r.URL.Query()
+	limit, count := intParam(q, "limit"), intParam(q, "count")
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+	min := func(a, b int) int {
+		if a < b {
+			return a
+		}
+		return b
+	}
+
+	var start, end int
+	var before, after string
+	switch {
+	case q.Get("after") != "":
+		i := index(things, q.Get("after"))
+		if i < 0 {
+			return listingThing(nil, "", "")
+		}
+		start = i + 1
+		end = min(start+limit, len(things))
+		if start < end && start > 0 && count > 0 {
+			before = fullname(things[start])
+		}
+		if start < end && end < len(things) {
+			after = fullname(things[end-1])
+		}
+	case q.Get("before") != "":
+		end = index(things, q.Get("before"))
+		if end < 0 {
+			return listingThing(nil, "", "")
+		}
+		start = end - min(limit, end)
+		if start < end && start > 0 {
+			before = fullname(things[start])
+		}
+		if start < end && count > 0 {
+			after = fullname(things[end-1])
+		}
+	default:
+		end = min(limit, len(things))
+		if end < len(things) {
+			after = fullname(things[end-1])
+		}
+	}
+	return listingThing(things[start:end], before, after)
+}
+
+// comments serves the link and comment tree of p. The depth and limit parameters replace the comments that
+// are cut off with more stubs: depth is the number of levels shown and limit the number of replies shown
+// at each level. The comment parameter selects the subtree of a single comment.
+func (s *Server) comments(w http.ResponseWriter, r *http.Request, p post) {
+	q := r.URL.Query()
+	link := reddit.Fullname(fullname(p.link))
+	roots, parent, depth := p.comments, link, 0
+	if id := q.Get("comment"); id != "" {
+		c, par, d, ok := find(p.comments, "t1_"+id, link, 0)
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "Not Found", "error": 404})
+			return
+		}
+		roots, parent, depth = []reddit.Thing{c}, par, d
+	}
+	maxDepth := 0
+	if d := intParam(q, "depth"); d > 0 {
+		maxDepth = depth + d
+	}
+	tree := render(roots, link, parent, depth, maxDepth, intParam(q, "limit"))
+	writeJSON(w, http.StatusOK, []thing{listingThing([]reddit.Thing{p.link}, "", ""), listingThing(tree, "", "")})
+}
+
+// find returns the comment with the provided fullname, its parent and its depth.
+func find(things []reddit.Thing, name string, parent reddit.Fullname, depth int) (reddit.Thing, reddit.Fullname, int, bool) {
+	for _, t := range things {
+		c, ok := t.Data.(*reddit.Comment)
+		if !ok {
+			continue
+		}
+		if fullname(t) == name {
+			return t, parent, depth, true
+		}
+		if found, p, d, ok := find(c.Replies, name, reddit.Fullname(fullname(t)), depth+1); ok {
+			return found, p, d, ok
+		}
+	}
+	return reddit.Thing{}, "", 0, false
+}
+
+// render returns copies of the comments in things, at the provided depth, with their replies rendered
+// recursively and the comments past maxDepth and limit replaced by more stubs.
+func render(things []reddit.Thing, link, parent reddit.Fullname, depth, maxDepth, limit int) []reddit.Thing {
+	shown := things
+	if limit > 0 && len(things) > limit {
+		shown = things[:limit]
+	}
+	var out []reddit.Thing
+	for _, t := range shown {
+		c, ok := t.Data.(*reddit.Comment)
+		if !ok {
+			out = append(out, t)
+			continue
+		}
+		cc := *c
+		cc.Name, cc.Depth = reddit.Fullname(fullname(t)), depth
+		if cc.LinkID == "" {
+			cc.LinkID = link
+		}
+		if cc.ParentID == "" {
+			cc.ParentID = parent
+		}
+		switch {
+		case len(c.Replies) == 0:
+		case maxDepth > 0 && depth+1 >= maxDepth:
+			cc.Replies = reddit.Replies{more(c.Replies, cc.Name, depth+1)}
+		default:
+			cc.Replies = render(c.Replies, link, cc.Name, depth+1, maxDepth, limit)
+		}
+		out = append(out, reddit.Thing{Kind: t.Kind, Data: &cc})
+	}
+	if len(shown) < len(things) {
+		out = append(out, more(things[len(shown):], parent, depth))
+	}
+	return out
+}
+
+// more returns a more stub for things, counting all of their replies.
+func more(things []reddit.Thing, parent reddit.Fullname, depth int) reddit.Thing {
+	m := &reddit.More{ParentID: parent, Depth: depth}
+	for _, t := range things {
+		m.Children = append(m.Children, reddit.Fullname(fullname(t)).ID())
+		m.Count += size(t)
+	}
+	m.ID, m.Name = m.Children[0], reddit.Fullname("t1_"+m.Children[0])
+	return reddit.Thing{Kind: "more", Data: m}
+}
+
+// size returns the number of comments in the tree rooted at t.
+func size(t reddit.Thing) int {
+	c, ok := t.Data.(*reddit.Comment)
+	if !ok {
+		return 0
+	}
+	n := 1
+	for _, r := range c.Replies {
+		n += size(r)
+	}
+	return n
+}
@@ -0,0 +1,290 @@
+package reddittest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Redacted replaces credentials and tokens in recorded fixtures.
+const Redacted = "REDACTED"
+
+// Interaction is a request and the response to it, as stored in a fixture file.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request stored in a fixture file.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response stored in a fixture file.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// Headers and form or JSON fields whose values are redacted.
+var (
+	redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+	redactedFields  = []string{"password", "client_secret", "access_token", "refresh_token"}
+)
+
+// Recorder is an http.RoundTripper that records the requests it sends and their responses. Use it with
+// reddit.WithTransport to capture a session, then Save it to a fixture file for a Replayer. Credentials and
+// tokens are redacted when recorded.
+type Recorder struct {
+	// Transport sends the requests. http.DefaultTransport is used if it is nil.
+	Transport http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+}
+
+// RoundTrip implements http.RoundTripper for Recorder.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		if reqBody, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+	}
+	rt := r.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, Interaction{
+		Request: RecordedRequest{
+			Method: req.Method, URL: req.URL.String(), Header: redactHeader(req.Header), Body: redactBody(reqBody),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode, Header: redactHeader(resp.Header), Body: redactBody(respBody),
+		},
+	})
+	return resp, nil
+}
+
+// Interactions returns the interactions recorded so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Save writes the interactions recorded so far to file as JSON.
+func (r *Recorder) Save(file string) error {
+	data, err := json.MarshalIndent(r.Interactions(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling interactions failed: %v", err)
+	}
+	if err := ioutil.WriteFile(file, data, 0644); err != nil {
+		return fmt.Errorf("failed to save interactions to %s: %v", file, err)
+	}
+	return nil
+}
+
+func redactHeader(h http.Header) http.Header {
+	h = h.Clone()
+	for _, name := range redactedHeaders {
+		if _, ok := h[name]; ok {
+			h.Set(name, Redacted)
+		}
+	}
+	return h
+}
+
+// redactBody redacts the credentials and tokens in a form encoded or JSON object body.
+func redactBody(body []byte) string {
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(body, &obj) == nil {
+		redacted := false
+		for _, f := range redactedFields {
+			if _, ok := obj[f]; ok {
+				obj[f], redacted = json.RawMessage(`"`+Redacted+`"`), true
+			}
+		}
+		if !redacted {
+			return string(body)
+		}
+		data, _ := json.Marshal(obj)
+		return string(data)
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return string(body)
+	}
+	redacted := false
+	for _, f := range redactedFields {
+		if _, ok := form[f]; ok {
+			form.Set(f, Redacted)
+			redacted = true
+		}
+	}
+	if !redacted {
+		return string(body)
+	}
+	return form.Encode()
+}
+
+// Replayer is an http.RoundTripper that answers requests with the responses in a fixture. Requests are
+// matched to recorded ones by method, path and query, ignoring the host, the order of query parameters
+// and the count parameter. Each recorded interaction is replayed once, in the order recorded.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer returns a Replayer for interactions.
+func NewReplayer(interactions []Interaction) *Replayer {
+	return &Replayer{interactions: interactions, used: make([]bool, len(interactions))}
+}
+
+// LoadReplayer returns a Replayer for the interactions in a fixture file written by Recorder.Save.
+func LoadReplayer(file string) (*Replayer, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contents of %s: %v", file, err)
+	}
+	var interactions []Interaction
+	if err := json.Unmarshal(data, &interactions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal contents of %s to json: %v", file, err)
+	}
+	return NewReplayer(interactions), nil
+}
+
+// Remaining returns the recorded interactions that have not been replayed.
+func (r *Replayer) Remaining() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ret []Interaction
+	for i, in := range r.interactions {
+		if !r.used[i] {
+			ret = append(ret, in)
+		}
+	}
+	return ret
+}
+
+// RoundTrip implements http.RoundTripper for Replayer. It returns an error describing how the request
+// differs from the recorded ones if no recorded interaction matches it.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := requestKey(req.Method, req.URL)
+	for i, in := range r.interactions {
+		if r.used[i] {
+			continue
+		}
+		u, err := url.Parse(in.Request.URL)
+		if err != nil || requestKey(in.Request.Method, u) != key {
+			continue
+		}
+		r.used[i] = true
+		return &http.Response{
+			Status:     fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode: in.Response.StatusCode,
+			Proto:      "HTTP/1.1", ProtoMajor: 1, ProtoMinor: 1,
+			Header:        in.Response.Header.Clone(),
+			Body:          ioutil.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, r.unexpected(req)
+}
+
+// normalizedQuery returns the query of u with sorted parameters and values, without count.
+func normalizedQuery(u *url.URL) url.Values {
+	q := u.Query()
+	q.Del("count")
+	for _, v := range q {
+		sort.Strings(v)
+	}
+	return q
+}
+
+func requestKey(method string, u *url.URL) string {
+	return method + " " + u.Path + "?" + normalizedQuery(u).Encode()
+}
+
+// unexpected returns an error listing the differences between req and the unused recorded requests with
+// the same method and path, or all unused recorded requests if there are none.
+func (r *Replayer) unexpected(req *http.Request) error {
+	var samePath, others []string
+	for i, in := range r.interactions {
+		u, err := url.Parse(in.Request.URL)
+		if r.used[i] || err != nil {
+			continue
+		}
+		line := fmt.Sprintf("\n  recorded %s %s", in.Request.Method, u.RequestURI())
+		if in.Request.Method == req.Method && u.Path == req.URL.Path {
+			samePath = append(samePath, line+queryDiff(normalizedQuery(req.URL), normalizedQuery(u)))
+		} else {
+			others = append(others, line)
+		}
+	}
+	msg := fmt.Sprintf("reddittest: unexpected request %s %s", req.Method, req.URL.RequestURI())
+	switch {
+	case len(samePath) > 0:
+		msg += strings.Join(samePath, "")
+	case len(others) > 0:
+		msg += strings.Join(others, "")
+	default:
+		msg += "\n  no recorded requests remain"
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// queryDiff returns a line for each parameter that differs between got and recorded.
+func queryDiff(got, recorded url.Values) string {
+	var names []string
+	for name := range got {
+		names = append(names, name)
+	}
+	for name := range recorded {
+		if _, ok := got[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var diff string
+	for _, name := range names {
+		g, w := strings.Join(got[name], ","), strings.Join(recorded[name], ",")
+		if g != w {
+			diff += fmt.Sprintf("\n    %s: got %q, recorded %q", name, g, w)
+		}
+	}
+	return diff
+}
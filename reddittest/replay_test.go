@@ -0,0 +1,70 @@
+package reddittest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	reddit "github.com/sridharv/reddit-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s := NewServer()
+	defer s.Close()
+	s.AddListing("/r/golang/new", links(3)...)
+	s.Credentials.Password, s.Credentials.ClientSecret = "hunter2", "s3cret"
+
+	dir, err := ioutil.TempDir("", "reddittest")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "session.json")
+
+	stream := func(cfg *reddit.Config) []string {
+		require.NoError(cfg.AuthScript(nil))
+		st := cfg.Stream(nil, &reddit.SubRedditPosts{SubReddit: "golang", Sort: reddit.LinksNew, ListingOptions: reddit.ListingOptions{Limit: 2}})
+		var got []reddit.Thing
+		for st.Next() {
+			got = append(got, st.Thing())
+		}
+		require.NoError(st.Error())
+		return ids(got)
+	}
+
+	rec := &Recorder{}
+	require.Equal([]string{"a", "b", "c"}, stream(s.Config().Configure(reddit.WithTransport(rec))))
+	require.NoError(rec.Save(file))
+	data, err := ioutil.ReadFile(file)
+	require.NoError(err)
+	for _, secret := range []string{s.Credentials.Password, s.Credentials.ClientSecret, s.Token} {
+		require.NotContains(string(data), secret)
+	}
+	interactions := rec.Interactions()
+	require.Len(interactions, 3)
+	require.Equal("grant_type=password&password=REDACTED&username=user", interactions[0].Request.Body)
+	require.Equal([]string{Redacted}, interactions[0].Request.Header["Authorization"])
+	require.Contains(interactions[0].Response.Body, `"access_token":"REDACTED"`)
+
+	// The replayed session makes no requests to the server. Hosts and the count parameter are ignored.
+	s.Close()
+	rep, err := LoadReplayer(file)
+	require.NoError(err)
+	cfg := (&reddit.Config{Credentials: s.Credentials}).Configure(reddit.WithTransport(rep), reddit.WithLimiter(reddit.NewLimiter()))
+	require.Equal([]string{"a", "b", "c"}, stream(cfg))
+	require.Empty(rep.Remaining())
+
+	rep = NewReplayer(interactions[1:])
+	cfg.Configure(reddit.WithTransport(rep))
+	err = cfg.Get(nil, reddit.RedditAPIURL+"/r/golang/new.json?limit=3&after=t3_b", nil)
+	require.Error(err)
+	require.True(strings.HasSuffix(err.Error(), `reddittest: unexpected request GET /r/golang/new.json?limit=3&after=t3_b
+  recorded GET /r/golang/new.json?limit=2
+    after: got "t3_b", recorded ""
+    limit: got "3", recorded "2"
+  recorded GET /r/golang/new.json?after=t3_b&count=2&limit=2
+    limit: got "3", recorded "2"`), err.Error())
+}
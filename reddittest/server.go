@@ -0,0 +1,278 @@
+// Package reddittest provides a fake reddit API server for testing code that uses the reddit package.
+//
+// A Server is an httptest.Server that issues OAuth tokens and serves listings and comment trees from
+// fixtures held in memory. Listings are paged with reddit's after, before, count and limit semantics,
+// comment trees are cut into more stubs, every response carries rate limit headers and errors can be
+// injected into any request:
+//
+//	s := reddittest.NewServer()
+//	defer s.Close()
+//	s.AddListing("/r/golang/new", reddittest.Link("a", "First"), reddittest.Link("b", "Second"))
+//
+//	cfg := s.Config()
+//	if err := cfg.AuthScript(s.Client()); err != nil {
+//		...
+//	}
+//	stream := cfg.Stream(s.Client(), &reddit.SubRedditPosts{SubReddit: "golang", Sort: reddit.LinksNew})
+//
+// Real sessions can be captured with a Recorder and replayed without network access with a Replayer. Both
+// are http.RoundTrippers to be used with reddit.WithTransport.
+package reddittest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	reddit "github.com/sridharv/reddit-go"
+)
+
+// TokenPath is the path of the endpoint that issues OAuth tokens.
+const TokenPath = "/api/v1/access_token"
+
+// Server is a fake reddit API server. Fixtures may be added and failures injected while it is running.
+type Server struct {
+	*httptest.Server
+
+	// Credentials accepted by the token endpoint. They are also used by Config.
+	Credentials reddit.Credentials
+	// Token is the OAuth token issued by the token endpoint and required by every other endpoint.
+	Token string
+
+	mu       sync.Mutex
+	listings map[string][]reddit.Thing
+	posts    map[string]post
+	failures []Failure
+	limit    RateLimit
+	used     int
+	period   time.Time
+	requests []string
+}
+
+type post struct {
+	link     reddit.Thing
+	comments []reddit.Thing
+}
+
+// RateLimit is the rate limit enforced by a Server and reported in the X-Ratelimit-* headers of every API
+// response. Requests over the limit get a 429 Too Many Requests response.
+type RateLimit struct {
+	Requests int           // Requests allowed in each period.
+	Period   time.Duration // Length of a period.
+}
+
+// DefaultRateLimit is the rate limit of a new Server.
+var DefaultRateLimit = RateLimit{Requests: 600, Period: 10 * time.Minute}
+
+// Failure is an error response returned by a Server instead of the response to a request.
+type Failure struct {
+	Path       string      // Path of the requests to fail, for example /r/golang/new.json. Empty matches every request.
+	StatusCode int         // HTTP status code of the response.
+	Body       string      // Body of the response.
+	Header     http.Header // Headers of the response. These replace the rate limit headers of the same name.
+	Times      int         // Number of requests to fail. Zero fails a single request.
+}
+
+// NewServer starts and returns a new Server. Call Close when done with it.
+func NewServer() *Server {
+	s := &Server{
+		Credentials: reddit.Credentials{
+			Username:     "user",
+			Password:     "password",
+			ClientID:     "client-id",
+			ClientSecret: "client-secret",
+			UserAgent:    "reddittest",
+		},
+		Token:    "reddittest-token",
+		listings: map[string][]reddit.Thing{},
+		posts:    map[string]post{},
+		limit:    DefaultRateLimit,
+		period:   time.Now(),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Options returns the options that point a reddit.Config at s. They include a new Limiter, so that the rate
+// limit of s does not affect requests to other servers.
+func (s *Server) Options() []reddit.Option {
+	return []reddit.Option{
+		reddit.WithAPIURL(s.URL), reddit.WithAuthURL(s.URL + TokenPath), reddit.WithWebURL(s.URL),
+		reddit.WithLimiter(reddit.NewLimiter()),
+	}
+}
+
+// Config returns an unauthenticated reddit.Config that uses s.Credentials and makes all requests to s.
+func (s *Server) Config() *reddit.Config {
+	return (&reddit.Config{Credentials: s.Credentials}).Configure(s.Options()...)
+}
+
+// AddListing serves things as a listing at path, for example /r/golang/new. The things are served in the
+// order given and replace any listing previously added at path. Paths are matched with and without a .json
+// suffix.
+func (s *Server) AddListing(path string, things ...reddit.Thing) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listings[strings.TrimSuffix(path, ".json")] = things
+}
+
+// AddComments serves link and its comment tree at /comments/{id} and /r/{subreddit}/comments/{id}. Replies
+// are nested in the Replies of each Comment. LinkID, ParentID and Depth are filled in when served.
+func (s *Server) AddComments(link reddit.Thing, comments ...reddit.Thing) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.posts[reddit.Fullname(fullname(link)).ID()] = post{link: link, comments: comments}
+}
+
+// Fail injects a failure. Failures are matched in the order they were added.
+func (s *Server) Fail(f Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if f.Times == 0 {
+		f.Times = 1
+	}
+	s.failures = append(s.failures, f)
+}
+
+// SetRateLimit replaces the rate limit of s and starts a new period.
+func (s *Server) SetRateLimit(l RateLimit) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limit, s.used, s.period = l, 0, time.Now()
+}
+
+// Requests returns the requests made to s so far, as the method followed by the path and query.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.RequestURI())
+
+	limited := r.URL.Path != TokenPath && s.rateLimit(w.Header())
+	if f, ok := s.failure(r.URL.Path); ok {
+		for k, v := range f.Header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(f.StatusCode)
+		fmt.Fprint(w, f.Body)
+		return
+	}
+	if r.URL.Path == TokenPath {
+		s.token(w, r)
+		return
+	}
+	if !s.authorized(r) {
+		writeJSON(w, http.StatusUnauthorized, map[string]interface{}{"message": "Unauthorized", "error": 401})
+		return
+	}
+	if limited {
+		writeJSON(w, http.StatusTooManyRequests, map[string]interface{}{"message": "Too Many Requests", "error": 429})
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, ".json")
+	if id, ok := commentsID(path); ok {
+		if p, ok := s.posts[id]; ok {
+			s.comments(w, r, p)
+			return
+		}
+	}
+	if things, ok := s.listings[path]; ok {
+		writeJSON(w, http.StatusOK, listing(things, r))
+		return
+	}
+	writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "Not Found", "error": 404})
+}
+
+// rateLimit counts a request against the rate limit and sets the rate limit headers. It returns true
+// if the rate limit was already used up, in which case the request is not counted.
+func (s *Server) rateLimit(h http.Header) bool {
+	if s.limit.Requests <= 0 {
+		return false
+	}
+	now := time.Now()
+	if s.limit.Period > 0 && now.Sub(s.period) >= s.limit.Period {
+		s.used, s.period = 0, now
+	}
+	limited := s.used >= s.limit.Requests
+	if !limited {
+		s.used++
+	}
+	remaining := s.limit.Requests - s.used
+	reset := int(math.Ceil((s.limit.Period - now.Sub(s.period)).Seconds()))
+	h.Set("X-Ratelimit-Used", strconv.Itoa(s.used))
+	h.Set("X-Ratelimit-Remaining", strconv.Itoa(remaining))
+	h.Set("X-Ratelimit-Reset", strconv.Itoa(reset))
+	return limited
+}
+
+// failure returns the first injected failure matching path and uses it up.
+func (s *Server) failure(path string) (Failure, bool) {
+	for i, f := range s.failures {
+		if f.Path != "" && f.Path != path {
+			continue
+		}
+		if s.failures[i].Times--; s.failures[i].Times == 0 {
+			s.failures = append(s.failures[:i], s.failures[i+1:]...)
+		}
+		return f, true
+	}
+	return Failure{}, false
+}
+
+// token issues a token if the request carries s.Credentials. Like reddit, an invalid client is rejected
+// with 401 while an invalid username or password is reported in the body of a 200 response.
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	id, secret, ok := r.BasicAuth()
+	if r.Method != http.MethodPost || !ok || id != s.Credentials.ClientID || secret != s.Credentials.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]interface{}{"message": "Unauthorized", "error": 401})
+		return
+	}
+	// The body is form encoded, but the Content-Type header is not required.
+	body, _ := ioutil.ReadAll(r.Body)
+	form, _ := url.ParseQuery(string(body))
+	if form.Get("grant_type") != "password" || form.Get("username") != s.Credentials.Username ||
+		form.Get("password") != s.Credentials.Password {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"error": "invalid_grant"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": s.Token, "token_type": "bearer", "expires_in": 86400, "scope": "*",
+	})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	fields := strings.Fields(r.Header.Get("Authorization"))
+	return len(fields) == 2 && strings.EqualFold(fields[0], "bearer") && fields[1] == s.Token
+}
+
+// commentsID returns the link ID of a /comments/{id} or /r/{subreddit}/comments/{id}/{slug} path.
+func commentsID(path string) (string, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) >= 3 && parts[0] == "r" {
+		parts = parts[2:]
+	}
+	if len(parts) < 2 || parts[0] != "comments" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+func writeJSON(w http.ResponseWriter, status int, val interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(val)
+}
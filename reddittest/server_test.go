@@ -0,0 +1,200 @@
+package reddittest
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	reddit "github.com/sridharv/reddit-go"
+	"github.com/stretchr/testify/require"
+)
+
+func authed(t *testing.T, s *Server) *reddit.Config {
+	cfg := s.Config()
+	require.NoError(t, cfg.AuthScript(s.Client()))
+	return cfg
+}
+
+func links(n int) []reddit.Thing {
+	things := make([]reddit.Thing, n)
+	for i := range things {
+		id := string(rune('a' + i))
+		things[i] = Link(id, "Link "+id)
+	}
+	return things
+}
+
+func ids(things []reddit.Thing) []string {
+	var ret []string
+	for _, t := range things {
+		ret = append(ret, t.ID)
+	}
+	return ret
+}
+
+func TestServer_Auth(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s := NewServer()
+	defer s.Close()
+
+	cfg := s.Config()
+	require.Error(cfg.Get(s.Client(), reddit.RedditAPIURL+"/r/golang/new.json", nil))
+	require.NoError(cfg.AuthScript(s.Client()))
+	require.Equal(reddit.AuthToken{Token: s.Token, Type: "bearer", Expires: cfg.AuthToken.Expires}, cfg.AuthToken)
+
+	bad := s.Config()
+	bad.Credentials.Password = "wrong"
+	require.Error(bad.AuthScript(s.Client()))
+	bad = s.Config()
+	bad.Credentials.ClientSecret = "wrong"
+	err := bad.AuthScript(s.Client())
+	require.IsType(&reddit.StatusError{}, err)
+	require.Equal(http.StatusUnauthorized, err.(*reddit.StatusError).StatusCode)
+}
+
+func TestServer_Listing(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s := NewServer()
+	defer s.Close()
+	s.AddListing("/r/golang/new", links(5)...)
+	cfg := authed(t, s)
+
+	stream := cfg.Stream(s.Client(), &reddit.SubRedditPosts{SubReddit: "golang", Sort: reddit.LinksNew, ListingOptions: reddit.ListingOptions{Limit: 2}})
+	var got []reddit.Thing
+	for stream.Next() {
+		got = append(got, stream.Thing())
+	}
+	require.NoError(stream.Error())
+	require.Equal([]string{"a", "b", "c", "d", "e"}, ids(got))
+	require.Equal([]string{
+		"POST /api/v1/access_token",
+		"GET /r/golang/new.json?limit=2",
+		"GET /r/golang/new.json?after=t3_b&count=2&limit=2",
+		"GET /r/golang/new.json?after=t3_d&count=4&limit=2",
+	}, s.Requests())
+
+	page := func(q string) (before, after string, names []string) {
+		var l reddit.Thing
+		require.NoError(cfg.Get(s.Client(), reddit.RedditAPIURL+"/r/golang/new.json?"+q, &l))
+		listing := l.Data.(*reddit.Listing)
+		return listing.Before, listing.After, ids(listing.Children)
+	}
+	cases := []struct {
+		query, before, after string
+		ids                  []string
+	}{
+		{"after=t3_b&limit=2", "", "t3_d", []string{"c", "d"}},
+		{"after=t3_b&limit=2&count=2", "t3_c", "t3_d", []string{"c", "d"}},
+		{"after=t3_d&limit=2&count=4", "t3_e", "", []string{"e"}},
+		{"before=t3_e&limit=2", "t3_c", "", []string{"c", "d"}},
+		{"before=t3_e&limit=2&count=3", "t3_c", "t3_d", []string{"c", "d"}},
+		{"before=t3_c&limit=5&count=3", "", "t3_b", []string{"a", "b"}},
+		{"after=t3_z", "", "", nil},
+	}
+	for _, c := range cases {
+		before, after, names := page(c.query)
+		require.Equal(c.before, before, c.query)
+		require.Equal(c.after, after, c.query)
+		require.Equal(c.ids, names, c.query)
+	}
+}
+
+func TestServer_Comments(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s := NewServer()
+	defer s.Close()
+	s.AddComments(Link("x", "Post"),
+		Comment("a", "A", Comment("b", "B", Comment("c", "C")), Comment("d", "D")),
+		Comment("e", "E"),
+		Comment("f", "F", Comment("g", "G")),
+	)
+	cfg := authed(t, s)
+
+	link, comments, err := cfg.Comments(s.Client(), &reddit.Comments{Link: "t3_x", Depth: 2, Limit: 2})
+	require.NoError(err)
+	require.Equal("Post", link.Title)
+	// The last comment is a more stub, which has no ID in the Thing.
+	require.Equal([]string{"a", "e", ""}, ids(comments))
+
+	a := comments[0].Data.(*reddit.Comment)
+	require.Equal(reddit.Fullname("t3_x"), a.LinkID)
+	require.Equal(reddit.Fullname("t3_x"), a.ParentID)
+	require.Equal([]string{"b", "d"}, ids(a.Replies))
+	b := a.Replies[0].Data.(*reddit.Comment)
+	require.Equal(1, b.Depth)
+	require.Equal(reddit.Fullname("t1_a"), b.ParentID)
+	require.Equal(&reddit.More{Children: []string{"c"}, Count: 1, Depth: 2, ID: "c", Name: "t1_c", ParentID: "t1_b"}, b.Replies[0].Data)
+	require.Equal(&reddit.More{Children: []string{"f"}, Count: 2, Depth: 0, ID: "f", Name: "t1_f", ParentID: "t3_x"}, comments[2].Data)
+
+	_, comments, err = cfg.Comments(s.Client(), &reddit.Comments{Link: "t3_x", Comment: "t1_b"})
+	require.NoError(err)
+	require.Equal([]string{"b"}, ids(comments))
+	b = comments[0].Data.(*reddit.Comment)
+	require.Equal(1, b.Depth)
+	require.Equal([]string{"c"}, ids(b.Replies))
+}
+
+func TestServer_Failures(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s := NewServer()
+	defer s.Close()
+	s.AddListing("/r/golang/hot", links(1)...)
+	cfg := authed(t, s)
+	u := reddit.RedditAPIURL + "/r/golang/hot.json"
+
+	s.Fail(Failure{Path: "/r/golang/hot.json", StatusCode: http.StatusInternalServerError, Body: "oops", Times: 2})
+	for i := 0; i < 2; i++ {
+		err := cfg.Get(s.Client(), u, nil)
+		require.IsType(&reddit.StatusError{}, err)
+		require.Equal("oops", string(err.(*reddit.StatusError).Body))
+	}
+	require.NoError(cfg.Get(s.Client(), u, nil))
+
+	// Rate limited requests are retried once the reported period ends.
+	s.Fail(Failure{StatusCode: http.StatusTooManyRequests, Header: http.Header{"X-Ratelimit-Reset": {"0"}}})
+	require.NoError(cfg.Get(s.Client(), u, nil))
+
+	err := cfg.Get(s.Client(), reddit.RedditAPIURL+"/r/missing/hot.json", nil)
+	require.IsType(&reddit.StatusError{}, err)
+	require.Equal(http.StatusNotFound, err.(*reddit.StatusError).StatusCode)
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s := NewServer()
+	defer s.Close()
+	s.AddListing("/r/golang/hot", links(1)...)
+	s.SetRateLimit(RateLimit{Requests: 2, Period: time.Minute})
+
+	get := func() *http.Response {
+		req, err := http.NewRequest(http.MethodGet, s.URL+"/r/golang/hot.json", nil)
+		require.NoError(err)
+		req.Header.Set("Authorization", "bearer "+s.Token)
+		resp, err := s.Client().Do(req)
+		require.NoError(err)
+		resp.Body.Close()
+		return resp
+	}
+	resp := get()
+	require.Equal(http.StatusOK, resp.StatusCode)
+	require.Equal("1", resp.Header.Get("X-Ratelimit-Used"))
+	require.Equal("1", resp.Header.Get("X-Ratelimit-Remaining"))
+	require.Equal("60", resp.Header.Get("X-Ratelimit-Reset"))
+
+	// The request that uses up the limit reports it, and every request after it is refused.
+	resp = get()
+	require.Equal(http.StatusOK, resp.StatusCode)
+	require.Equal("2", resp.Header.Get("X-Ratelimit-Used"))
+	require.Equal("0", resp.Header.Get("X-Ratelimit-Remaining"))
+	for i := 0; i < 2; i++ {
+		resp = get()
+		require.Equal(http.StatusTooManyRequests, resp.StatusCode)
+		require.Equal("2", resp.Header.Get("X-Ratelimit-Used"))
+		require.Equal("0", resp.Header.Get("X-Ratelimit-Remaining"))
+	}
+}
@@ -16,83 +16,69 @@ import (
 
 	"strconv"
 	"strings"
-
-	"flag"
+	"sync"
 
 	"github.com/jonboulle/clockwork"
-	"github.com/serenize/snaker"
 	"github.com/stretchr/testify/require"
 )
 
-var convert = flag.String("convert", "", "File containing API to generate")
-
-// This is not really a test but more a code generation tool and (ab)uses the test infrastructure.
-// To use this, copy and paste the contents of a table from https://github.com/reddit/reddit/wiki/JSON
-// into a file. Then run go test -v -run TestConvert --convert=<path-to-file>
-// This will generate the internal JSON structure for that type.
-// You can then copy+paste the structure into a go file after verifying that things look fine to you.
-func TestConvert(t *testing.T) {
-	if *convert == "" {
-		return
-	}
-	conversions := map[string]string{
-		"object":      "json.RawMessage",
-		"list<thing>": "[]Thing",
-		"boolean":     "bool",
-		"long":        "int64",
-	}
-
-	data, err := ioutil.ReadFile(*convert)
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	lines := bytes.Split(data, []byte("\n"))
-	for _, line := range lines {
-		l := bytes.TrimSpace(line)
-		if len(l) == 0 {
-			continue
-		}
-		tokens := bytes.Split(l, []byte("\t"))
-		name := snaker.SnakeToCamel(string(bytes.Title(tokens[1])))
-		if name == "Id" {
-			name = "ID"
-		}
-		t := string(bytes.ToLower(tokens[0]))
-		converted, ok := conversions[t]
-		if !ok {
-			converted = t
-		}
-		fmt.Printf("\t%s %s `json:\"%s\"`\n", name, converted, string(tokens[1]))
-	}
-}
-
 type response struct {
-	requestURL string
-	headers    map[string]string
-	body       string
-	statusCode int
-	response   string
-	err        string
+	method          string // If set, the method of the request must match.
+	requestURL      string
+	headers         map[string]string
+	body            string
+	statusCode      int
+	response        string
+	responseHeaders map[string]string
+	err             string
+	// If bodyContains is set, the request body must contain each of its values instead of matching body.
+	bodyContains []string
 }
 
 type mocks struct {
-	time      time.Time
-	orig      doer
-	origClock clockwork.Clock
-	ctr       int
-	expected  []response
+	mu          sync.Mutex
+	time        time.Time
+	orig        doer
+	origClock   clockwork.Clock
+	origLimiter Limiter
+	ctr         int
+	expected    []response
+	// If unordered is set, requests may arrive in any order and are matched to responses by URL.
+	unordered bool
+	used      []bool
 }
 
-func (f *mocks) do(req *http.Request, client *http.Client) (*http.Response, error) {
+// next returns the response to req.
+func (f *mocks) next(req *http.Request) (response, error) {
 	defer func() { f.ctr++ }()
 	if f.ctr >= len(f.expected) {
-		return nil, fmt.Errorf("unexpected request received: all responses finished (%d present)", len(f.expected))
+		return response{}, fmt.Errorf("unexpected request received: all responses finished (%d present)", len(f.expected))
+	}
+	if !f.unordered {
+		return f.expected[f.ctr], nil
+	}
+	for i, r := range f.expected {
+		if !f.used[i] && req.URL.String() == r.requestURL {
+			f.used[i] = true
+			return r, nil
+		}
+	}
+	return response{}, fmt.Errorf("unexpected request received for %s", req.URL)
+}
+
+func (f *mocks) do(req *http.Request, client *http.Client) (*http.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.next(req)
+	if err != nil {
+		return nil, err
 	}
-	r := f.expected[f.ctr]
 	if req.URL.String() != r.requestURL {
 		return nil, fmt.Errorf("expected URL: %v, got %s", r.requestURL, req.URL)
 	}
+	if r.method != "" && req.Method != r.method {
+		return nil, fmt.Errorf("expected method %s for %s, got %s", r.method, r.requestURL, req.Method)
+	}
 	if r.err != "" {
 		return nil, fmt.Errorf("%s", r.err)
 	}
@@ -111,24 +97,42 @@ func (f *mocks) do(req *http.Request, client *http.Client) (*http.Response, erro
 		}
 		d = string(data)
 	}
-	if d != r.body {
+	if r.bodyContains != nil {
+		for _, s := range r.bodyContains {
+			if !strings.Contains(d, s) {
+				return nil, fmt.Errorf("expected body containing %s, got %s", s, d)
+			}
+		}
+	} else if d != r.body {
 		return nil, fmt.Errorf("expected body %s, got %s", r.body, d)
 	}
 
+	header := http.Header{}
+	for k, v := range r.responseHeaders {
+		header.Set(k, v)
+	}
 	return &http.Response{
 		StatusCode: r.statusCode,
+		Header:     header,
 		Body:       ioutil.NopCloser(bytes.NewBufferString(r.response)),
 	}, nil
 }
 
 func (f *mocks) reset() {
-	defaultDoer = f.orig
+	defaultDoer, clock, defaultLimiter = f.orig, f.origClock, f.origLimiter
 }
 
 func mock(r ...response) *mocks {
-	d := &mocks{orig: defaultDoer, expected: r, origClock: clock, time: time.Now()}
+	d := &mocks{orig: defaultDoer, expected: r, origClock: clock, origLimiter: defaultLimiter, time: time.Now()}
 	clock = clockwork.NewFakeClockAt(d.time)
-	defaultDoer = d
+	defaultDoer, defaultLimiter = d, NewLimiter()
+	return d
+}
+
+// mockUnordered is like mock, but accepts requests in any order.
+func mockUnordered(r ...response) *mocks {
+	d := mock(r...)
+	d.unordered, d.used = true, make([]bool, len(r))
 	return d
 }
 
@@ -201,6 +205,38 @@ var requestHeaders = map[string]string{
 	"Authorization": "bearer test-token",
 }
 
+// authedConfig returns a Config holding a token that is valid for an hour from the mocked time.
+func authedConfig(m *mocks) *Config {
+	return &Config{
+		Credentials: testConfig.Credentials,
+		AuthToken:   AuthToken{Token: "test-token", Type: "bearer", Expires: m.time.Add(time.Hour).Unix()},
+	}
+}
+
+// get returns a successful response to a GET request.
+func get(url, resp string) response {
+	return response{method: http.MethodGet, statusCode: 200, headers: requestHeaders, requestURL: url, response: resp}
+}
+
+// post returns a successful response to a POST request with the provided form body.
+func post(url, body, resp string) response {
+	return response{method: http.MethodPost, statusCode: 200, headers: requestHeaders, requestURL: url, body: body, response: resp}
+}
+
+// put returns a successful response to a PUT request with the provided form body.
+func put(url, body, resp string) response {
+	r := post(url, body, resp)
+	r.method = http.MethodPut
+	return r
+}
+
+// del returns a successful response to a DELETE request.
+func del(url, resp string) response {
+	r := get(url, resp)
+	r.method = http.MethodDelete
+	return r
+}
+
 func TestConfig_ScriptAuth(t *testing.T) {
 	m := mock(authRequest)
 	defer m.reset()
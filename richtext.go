@@ -0,0 +1,95 @@
+package reddit
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// RichtextSegment types.
+const (
+	RichtextText  = "text"
+	RichtextEmoji = "emoji"
+)
+
+// RichtextSegment is a single segment of richtext flair. Text segments hold Text, emoji segments
+// hold the Alias (for example ":gopher:") and URL of the emoji image.
+type RichtextSegment struct {
+	Type  string `json:"e"`
+	Text  string `json:"t,omitempty"`
+	Alias string `json:"a,omitempty"`
+	URL   string `json:"u,omitempty"`
+}
+
+// FlairRichtext is flair made up of text and emoji segments, as sent by reddit in the
+// *_flair_richtext fields.
+type FlairRichtext []RichtextSegment
+
+// richtextOrText returns r, or the plain text flair as a single segment if r is empty.
+func richtextOrText(r FlairRichtext, text string) FlairRichtext {
+	if len(r) > 0 || text == "" {
+		return r
+	}
+	return FlairRichtext{{Type: RichtextText, Text: text}}
+}
+
+// Text renders r as plain text. Emoji are rendered as their alias.
+func (r FlairRichtext) Text() string {
+	var b strings.Builder
+	for _, s := range r {
+		if s.Type == RichtextEmoji {
+			b.WriteString(s.Alias)
+		} else {
+			b.WriteString(s.Text)
+		}
+	}
+	return b.String()
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`,
+	`(`, `\(`, `)`, `\)`, `#`, `\#`, `~`, `\~`, `<`, `\<`, `>`, `\>`, `|`, `\|`,
+)
+
+// Markdown renders r as Markdown. Text is escaped and emoji are rendered as inline images.
+func (r FlairRichtext) Markdown() string {
+	var b strings.Builder
+	for _, s := range r {
+		if s.Type == RichtextEmoji {
+			fmt.Fprintf(&b, "![%s](%s)", markdownEscaper.Replace(s.Alias), s.URL)
+		} else {
+			b.WriteString(markdownEscaper.Replace(s.Text))
+		}
+	}
+	return b.String()
+}
+
+// HTML renders r as HTML. Text is escaped and emoji are rendered as img elements with the class
+// flair-emoji.
+func (r FlairRichtext) HTML() string {
+	var b strings.Builder
+	for _, s := range r {
+		if s.Type == RichtextEmoji {
+			alias := html.EscapeString(s.Alias)
+			fmt.Fprintf(&b, `<img class="flair-emoji" src="%s" alt="%s" title="%s">`, html.EscapeString(s.URL), alias, alias)
+		} else {
+			b.WriteString(html.EscapeString(s.Text))
+		}
+	}
+	return b.String()
+}
+
+// LinkFlair returns the flair of l. Plain text flair is returned as a single text segment.
+func (l *Link) LinkFlair() FlairRichtext {
+	return richtextOrText(l.LinkFlairRichtext, l.LinkFlairText)
+}
+
+// AuthorFlair returns the flair of the author of l. Plain text flair is returned as a single text segment.
+func (l *Link) AuthorFlair() FlairRichtext {
+	return richtextOrText(l.AuthorFlairRichtext, l.AuthorFlairText)
+}
+
+// AuthorFlair returns the flair of the author of c. Plain text flair is returned as a single text segment.
+func (c *Comment) AuthorFlair() FlairRichtext {
+	return richtextOrText(c.AuthorFlairRichtext, c.AuthorFlairText)
+}
@@ -0,0 +1,45 @@
+package reddit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const richtextLink = `{"kind": "t3", "data": {
+	"id": "abc",
+	"link_flair_text": ":gopher: Help [me]",
+	"link_flair_richtext": [
+		{"e": "emoji", "a": ":gopher:", "u": "https://emoji.redditmedia.com/gopher.png"},
+		{"e": "text", "t": " Help [me] <now>"}
+	],
+	"link_flair_template_id": "8f2f3c3e-1234",
+	"link_flair_background_color": "#ff4500",
+	"link_flair_text_color": "light",
+	"link_flair_type": "richtext",
+	"author_flair_text": "Gopher",
+	"author_flair_richtext": [],
+	"author_flair_type": "text"
+}}`
+
+func TestFlairRichtext(t *testing.T) {
+	require := require.New(t)
+
+	var thing Thing
+	require.NoError(json.Unmarshal([]byte(richtextLink), &thing))
+	l := thing.Data.(*Link)
+	require.Equal("8f2f3c3e-1234", l.LinkFlairTemplateID)
+	require.Equal("#ff4500", l.LinkFlairBackgroundColor)
+	require.Equal("light", l.LinkFlairTextColor)
+
+	flair := l.LinkFlair()
+	require.Equal(":gopher: Help [me] <now>", flair.Text())
+	require.Equal(`![:gopher:](https://emoji.redditmedia.com/gopher.png) Help \[me\] \<now\>`, flair.Markdown())
+	require.Equal(`<img class="flair-emoji" src="https://emoji.redditmedia.com/gopher.png" alt=":gopher:" title=":gopher:"> Help [me] &lt;now&gt;`, flair.HTML())
+
+	require.Equal(FlairRichtext{{Type: RichtextText, Text: "Gopher"}}, l.AuthorFlair())
+	require.Nil((&Comment{}).AuthorFlair())
+
+	requireRoundTrip(t, &thing, &Thing{})
+}
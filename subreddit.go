@@ -0,0 +1,279 @@
+package reddit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-querystring/query"
+)
+
+// SubReddit fetches information about the subreddit with the provided name.
+func (c *Config) SubReddit(client *http.Client, name string) (*SubReddit, error) {
+	u := fmt.Sprintf("%s/r/%s/about.json", RedditAPIURL, name)
+	var t Thing
+	if err := c.Get(client, u, &t); err != nil {
+		return nil, err
+	}
+	s, ok := t.Data.(*SubReddit)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %s response from %s", t.Kind, u)
+	}
+	return s, nil
+}
+
+// infoBatchSize is the maximum number of items reddit accepts in a single /api/info request.
+const infoBatchSize = 100
+
+// SubRedditsByName fetches information about many subreddits at once. Subreddits that do not exist are
+// omitted from the result.
+func (c *Config) SubRedditsByName(client *http.Client, names ...string) ([]*SubReddit, error) {
+	var subs []*SubReddit
+	for start := 0; start < len(names); start += infoBatchSize {
+		end := start + infoBatchSize
+		if end > len(names) {
+			end = len(names)
+		}
+		u := fmt.Sprintf("%s/api/info.json?sr_name=%s", RedditAPIURL, url.QueryEscape(strings.Join(names[start:end], ",")))
+		l, err := c.listing(client, u)
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range l.Children {
+			if s, ok := child.Data.(*SubReddit); ok {
+				subs = append(subs, s)
+			}
+		}
+	}
+	return subs, nil
+}
+
+// RuleKind is the type of content a subreddit rule applies to.
+type RuleKind string
+
+// RuleLink, RuleComment and RuleAll are the supported kinds of subreddit rules.
+const (
+	RuleLink    RuleKind = "link"
+	RuleComment RuleKind = "comment"
+	RuleAll     RuleKind = "all"
+)
+
+// Rule is a single rule of a subreddit.
+type Rule struct {
+	CreatedUTC      Timestamp `json:"created_utc"`
+	Description     string    `json:"description"`
+	DescriptionHTML string    `json:"description_html"`
+	Kind            RuleKind  `json:"kind"`
+	Priority        int       `json:"priority"`
+	ShortName       string    `json:"short_name"`
+	ViolationReason string    `json:"violation_reason"`
+}
+
+// SubRedditRules holds the rules of a subreddit along with the site wide rules of reddit.
+type SubRedditRules struct {
+	Rules         []Rule          `json:"rules"`
+	SiteRules     []string        `json:"site_rules"`
+	SiteRulesFlow json.RawMessage `json:"site_rules_flow"`
+}
+
+// SubRedditRules fetches the rules of subreddit, ordered by priority.
+func (c *Config) SubRedditRules(client *http.Client, subreddit string) (*SubRedditRules, error) {
+	var r SubRedditRules
+	if err := c.Get(client, fmt.Sprintf("%s/r/%s/about/rules.json", RedditAPIURL, subreddit), &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// SubRedditSettings fetches the settings of a subreddit moderated by the authenticated user.
+func (c *Config) SubRedditSettings(client *http.Client, subreddit string) (*SubRedditSettings, error) {
+	u := fmt.Sprintf("%s/r/%s/about/edit.json", RedditAPIURL, subreddit)
+	var t Thing
+	if err := c.Get(client, u, &t); err != nil {
+		return nil, err
+	}
+	s, ok := t.Data.(*SubRedditSettings)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %s response from %s", t.Kind, u)
+	}
+	return s, nil
+}
+
+// UpdateSubRedditSettings saves the settings of a subreddit. reddit replaces all settings of the subreddit,
+// so s should be obtained from SubRedditSettings and modified rather than built from scratch.
+func (c *Config) UpdateSubRedditSettings(client *http.Client, s *SubRedditSettings) error {
+	if s.SubredditID == "" {
+		return fmt.Errorf("no subreddit id present")
+	}
+	form, err := query.Values(s)
+	if err != nil {
+		return err
+	}
+	form.Set("api_type", "json")
+	return c.Post(client, RedditAPIURL+"/api/site_admin", form, nil)
+}
+
+// Widget is a single sidebar, topbar or ID card widget of a subreddit. Only the fields common to most widget
+// kinds are decoded, Raw holds the full JSON of the widget.
+type Widget struct {
+	ID        string          `json:"id"`
+	Kind      string          `json:"kind"`
+	ShortName string          `json:"shortName"`
+	Text      string          `json:"text"`
+	TextHTML  string          `json:"textHtml"`
+	Data      json.RawMessage `json:"data"`
+	Styles    json.RawMessage `json:"styles"`
+	Raw       json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler for Widget. It decodes the common fields of the widget and
+// keeps the full JSON in Raw.
+func (w *Widget) UnmarshalJSON(b []byte) error {
+	type widget Widget
+	var v widget
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*w = Widget(v)
+	w.Raw = append(json.RawMessage(nil), b...)
+	return nil
+}
+
+// WidgetOrder is an ordered list of widget IDs.
+type WidgetOrder struct {
+	Order []string `json:"order"`
+}
+
+// Widgets holds all widgets of a subreddit and where they are displayed.
+type Widgets struct {
+	Items  map[string]*Widget `json:"items"`
+	Layout struct {
+		IDCard     string      `json:"idCardWidget"`
+		Moderators string      `json:"moderatorWidget"`
+		Sidebar    WidgetOrder `json:"sidebar"`
+		Topbar     WidgetOrder `json:"topbar"`
+	} `json:"layout"`
+}
+
+// Sidebar returns the widgets shown in the sidebar in display order.
+func (w *Widgets) Sidebar() []*Widget {
+	var widgets []*Widget
+	for _, id := range w.Layout.Sidebar.Order {
+		if widget, ok := w.Items[id]; ok {
+			widgets = append(widgets, widget)
+		}
+	}
+	return widgets
+}
+
+// Widgets fetches the widgets of subreddit. The old style sidebar is available as the Description of
+// the SubReddit returned by Config.SubReddit.
+func (c *Config) Widgets(client *http.Client, subreddit string) (*Widgets, error) {
+	var w Widgets
+	if err := c.Get(client, subredditAPIURL(subreddit, "widgets"), &w); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// SubRedditsWhere selects one of the site wide listings of subreddits.
+type SubRedditsWhere string
+
+// SubRedditsPopular, SubRedditsNew, SubRedditsDefault and SubRedditsPremium are the supported listings
+// for SubRedditListing.
+const (
+	SubRedditsPopular SubRedditsWhere = "popular"
+	SubRedditsNew     SubRedditsWhere = "new"
+	SubRedditsDefault SubRedditsWhere = "default"
+	SubRedditsPremium SubRedditsWhere = "premium"
+)
+
+// SubRedditListing is a query for a site wide listing of subreddits. It implements URLer and Lister
+// and can be used with Config.Stream to stream SubReddit values.
+type SubRedditListing struct {
+	ListingOptions
+	Where SubRedditsWhere `url:"-"`
+}
+
+// URL returns the URL to use when fetching the listing.
+func (s *SubRedditListing) URL() (string, error) {
+	v, err := query.Values(s)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/subreddits/%s.json?%s", RedditAPIURL, s.Where, v.Encode()), nil
+}
+
+// List returns the ListingOptions for SubRedditListing
+func (s *SubRedditListing) List() *ListingOptions { return &s.ListingOptions }
+
+// SubRedditSearch is a query for subreddits whose name or description match Query. It implements URLer
+// and Lister and can be used with Config.Stream to stream SubReddit values.
+type SubRedditSearch struct {
+	ListingOptions
+	Query         string `url:"q"`
+	Sort          string `url:"sort,omitempty"` // relevance or activity
+	IncludeOver18 bool   `url:"include_over_18,omitempty"`
+}
+
+// URL returns the URL to use when searching for subreddits.
+func (s *SubRedditSearch) URL() (string, error) {
+	v, err := query.Values(s)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/subreddits/search.json?%s", RedditAPIURL, v.Encode()), nil
+}
+
+// List returns the ListingOptions for SubRedditSearch
+func (s *SubRedditSearch) List() *ListingOptions { return &s.ListingOptions }
+
+// MySubRedditsWhere selects the relationship between the authenticated user and the subreddits listed
+// by MySubReddits.
+type MySubRedditsWhere string
+
+// MySubscriber, MyModerator and MyContributor are the supported relationships for MySubReddits.
+const (
+	MySubscriber  MySubRedditsWhere = "subscriber"
+	MyModerator   MySubRedditsWhere = "moderator"
+	MyContributor MySubRedditsWhere = "contributor"
+)
+
+// MySubReddits is a query for the subreddits the authenticated user subscribes to, moderates or contributes
+// to. It implements URLer and Lister and can be used with Config.Stream to stream SubReddit values.
+type MySubReddits struct {
+	ListingOptions
+	Where MySubRedditsWhere `url:"-"`
+}
+
+// URL returns the URL to use when fetching the subreddits of the authenticated user.
+func (m *MySubReddits) URL() (string, error) {
+	v, err := query.Values(m)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/subreddits/mine/%s.json?%s", RedditAPIURL, m.Where, v.Encode()), nil
+}
+
+// List returns the ListingOptions for MySubReddits
+func (m *MySubReddits) List() *ListingOptions { return &m.ListingOptions }
+
+func (c *Config) subscribe(client *http.Client, action string, names []string) error {
+	form := url.Values{"action": {action}, "sr_name": {strings.Join(names, ",")}}
+	if action == "sub" {
+		form.Set("skip_initial_defaults", "true")
+	}
+	return c.Post(client, RedditAPIURL+"/api/subscribe", form, nil)
+}
+
+// Subscribe subscribes the authenticated user to the subreddits with the provided names.
+func (c *Config) Subscribe(client *http.Client, names ...string) error {
+	return c.subscribe(client, "sub", names)
+}
+
+// Unsubscribe reverses Subscribe.
+func (c *Config) Unsubscribe(client *http.Client, names ...string) error {
+	return c.subscribe(client, "unsub", names)
+}
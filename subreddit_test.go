@@ -0,0 +1,146 @@
+package reddit
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-querystring/query"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_SubReddit(t *testing.T) {
+	m := mock(
+		get("https://oauth.reddit.com/r/golang/about.json", `{"kind": "t5", "data": {
+			"display_name": "golang", "name": "t5_2rc7j", "id": "2rc7j", "subscribers": 250000, "header_size": null
+		}}`),
+		get("https://oauth.reddit.com/api/info.json?sr_name=golang%2Crust", `{"kind": "Listing", "data": {"children": [
+			{"kind": "t5", "data": {"display_name": "golang"}},
+			{"kind": "t5", "data": {"display_name": "rust"}}
+		]}}`),
+		get("https://oauth.reddit.com/r/golang/about/rules.json", `{
+			"rules": [{"kind": "link", "short_name": "Be nice", "violation_reason": "Not nice", "priority": 0,
+				"description": "Be nice to others", "created_utc": 1500000000.0}],
+			"site_rules": ["Spam"],
+			"site_rules_flow": [{"reasonTextToShow": "Spam"}]
+		}`),
+	)
+	defer m.reset()
+
+	require := require.New(t)
+	c := authedConfig(m)
+
+	sub, err := c.SubReddit(nil, "golang")
+	require.NoError(err)
+	require.Equal(Fullname("t5_2rc7j"), sub.Name)
+	require.Equal(int64(250000), sub.Subscribers)
+
+	subs, err := c.SubRedditsByName(nil, "golang", "rust")
+	require.NoError(err)
+	require.Len(subs, 2)
+	require.Equal("rust", subs[1].DisplayName)
+
+	rules, err := c.SubRedditRules(nil, "golang")
+	require.NoError(err)
+	require.Equal([]Rule{{
+		Kind: RuleLink, ShortName: "Be nice", ViolationReason: "Not nice", Description: "Be nice to others", CreatedUTC: 1500000000,
+	}}, rules.Rules)
+	require.Equal([]string{"Spam"}, rules.SiteRules)
+}
+
+func TestConfig_SubRedditSettings(t *testing.T) {
+	settings := SubRedditSettings{
+		SubredditID: "t5_2rc7j", Title: "The Go Programming Language", SubredditType: "public",
+		ContentOptions: "any", HeaderHoverText: "Gophers", WikiMode: "modonly", SpoilersEnabled: true,
+	}
+	updated := settings
+	updated.Title = "Go"
+	form, err := query.Values(updated)
+	require.NoError(t, err)
+	form.Set("api_type", "json")
+
+	m := mock(
+		get("https://oauth.reddit.com/r/golang/about/edit.json", `{"kind": "subreddit_settings", "data": {
+			"subreddit_id": "t5_2rc7j", "title": "The Go Programming Language", "subreddit_type": "public",
+			"content_options": "any", "header_hover_text": "Gophers", "wikimode": "modonly", "spoilers_enabled": true
+		}}`),
+		post("https://oauth.reddit.com/api/site_admin", form.Encode(), `{"json": {"errors": []}}`),
+	)
+	defer m.reset()
+
+	require := require.New(t)
+	c := authedConfig(m)
+
+	s, err := c.SubRedditSettings(nil, "golang")
+	require.NoError(err)
+	require.Equal(settings, *s)
+	require.Equal("Gophers", form.Get("header-title"))
+	require.Equal("any", form.Get("link_type"))
+
+	s.Title = "Go"
+	require.NoError(c.UpdateSubRedditSettings(nil, s))
+}
+
+func TestConfig_Widgets(t *testing.T) {
+	m := mock(get("https://oauth.reddit.com/r/golang/api/widgets", `{
+		"items": {
+			"widget_a": {"id": "widget_a", "kind": "textarea", "shortName": "About", "text": "Hello"},
+			"widget_b": {"id": "widget_b", "kind": "rules", "shortName": "Rules", "data": [{"shortName": "Be nice"}]},
+			"widget_c": {"id": "widget_c", "kind": "id-card", "shortName": "Info"}
+		},
+		"layout": {"idCardWidget": "widget_c", "sidebar": {"order": ["widget_b", "widget_a"]}, "topbar": {"order": []}}
+	}`))
+	defer m.reset()
+
+	require := require.New(t)
+	w, err := authedConfig(m).Widgets(nil, "golang")
+	require.NoError(err)
+	require.Equal("widget_c", w.Layout.IDCard)
+
+	sidebar := w.Sidebar()
+	require.Len(sidebar, 2)
+	require.Equal("rules", sidebar[0].Kind)
+	require.JSONEq(`[{"shortName": "Be nice"}]`, string(sidebar[0].Data))
+	require.Equal("Hello", sidebar[1].Text)
+	require.Contains(string(sidebar[1].Raw), `"shortName": "About"`)
+}
+
+func subRedditsBody(after string, names ...string) string {
+	children := make([]string, len(names))
+	for i, n := range names {
+		children[i] = fmt.Sprintf(`{"kind": "t5", "data": {"display_name": "%s", "name": "t5_%s"}}`, n, n)
+	}
+	return fmt.Sprintf(`{"kind": "Listing", "data": {"after": "%s", "children": [%s]}}`, after, strings.Join(children, ","))
+}
+
+func TestConfig_StreamSubRedditSearch(t *testing.T) {
+	m := mock(
+		get("https://oauth.reddit.com/subreddits/search.json?limit=2&q=go", subRedditsBody("t5_golang", "go", "golang")),
+		get("https://oauth.reddit.com/subreddits/search.json?after=t5_golang&count=2&limit=2&q=go", subRedditsBody("", "gopher")),
+		post("https://oauth.reddit.com/api/subscribe", "action=sub&skip_initial_defaults=true&sr_name=golang%2Cgopher", "{}"),
+	)
+	defer m.reset()
+
+	require := require.New(t)
+	c := authedConfig(m)
+
+	stream := c.Stream(nil, &SubRedditSearch{Query: "go", ListingOptions: ListingOptions{Limit: 2}})
+	var names []string
+	for stream.Next() {
+		names = append(names, stream.Thing().Data.(*SubReddit).DisplayName)
+	}
+	require.NoError(stream.Error())
+	require.Equal([]string{"go", "golang", "gopher"}, names)
+
+	require.NoError(c.Subscribe(nil, "golang", "gopher"))
+}
+
+func TestSubRedditListers_URL(t *testing.T) {
+	u, err := (&SubRedditListing{Where: SubRedditsPopular, ListingOptions: ListingOptions{Limit: 100}}).URL()
+	require.NoError(t, err)
+	require.Equal(t, "https://oauth.reddit.com/subreddits/popular.json?limit=100", u)
+
+	u, err = (&MySubReddits{Where: MyModerator}).URL()
+	require.NoError(t, err)
+	require.Equal(t, "https://oauth.reddit.com/subreddits/mine/moderator.json?", u)
+}
@@ -0,0 +1,237 @@
+package reddit
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Thing holds attributes common to all reddit api entities. For Things of kind t1 to t6, ID and
+// Name (the fullname) are populated from the id sent by reddit in Data.
+//
+// See https://github.com/reddit/reddit/wiki/JSON
+type Thing struct {
+	ID   string      `json:"id"`
+	Name Fullname    `json:"name"`
+	Kind string      `json:"kind"`
+	Data interface{} `json:"data"`
+}
+
+type thingJSON struct {
+	ID   string          `json:"id"`
+	Name Fullname        `json:"name"`
+	Kind string          `json:"kind"`
+	Data json.RawMessage `json:"data"`
+}
+
+// UnmarshalJSON implements json.Unmarshaller for Thing. It performs this in two passes. In the
+// first pass the data is left unmarshalled. The value of kind is then used to determine the struct type
+// for Data, using the decoders registered with RegisterKind. Things of unknown kinds and data of unexpected
+// types are errors. Use DecodeOptions to handle them differently.
+func (t *Thing) UnmarshalJSON(b []byte) error { return DecodeOptions{}.decodeThing(b, t) }
+
+// decodeThing decodes b into t like Thing.UnmarshalJSON, with o.
+func (o DecodeOptions) decodeThing(b []byte, t *Thing) error {
+	var j thingJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	newData := o.kindDecoder(j.Kind)
+	if newData == nil {
+		if !o.KeepUnknownKinds {
+			return fmt.Errorf("unsupported kind: %s", j.Kind)
+		}
+		t.ID, t.Name, t.Kind, t.Data = j.ID, j.Name, j.Kind, append(json.RawMessage(nil), j.Data...)
+		return nil
+	}
+	val := newData()
+	if err := o.decodeData(j.Data, val); err != nil {
+		return err
+	}
+	if j.ID == "" && len(j.Kind) == 2 && j.Kind[0] == 't' {
+		// reddit sends the id inside data. Use it to populate the ID and fullname of the Thing.
+		var d struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(j.Data, &d); err == nil && d.ID != "" {
+			j.ID = d.ID
+			if j.Name == "" {
+				j.Name = Fullname(j.Kind + "_" + d.ID)
+			}
+		}
+	}
+	t.ID, t.Name, t.Kind, t.Data = j.ID, j.Name, j.Kind, val
+	return nil
+}
+
+// Listing contains paginated content from an API request.
+//
+// See https://github.com/reddit/reddit/wiki/JSON
+type Listing struct {
+	Before   string  `json:"before"`
+	After    string  `json:"after"`
+	Modhash  string  `json:"modhash"`
+	Children []Thing `json:"children"`
+}
+
+// Votable holds attributes related to voting.
+//
+// See https://github.com/reddit/reddit/wiki/JSON
+type Votable struct {
+	Ups   int  `json:"ups"`
+	Downs int  `json:"downs"`
+	Likes Vote `json:"likes"`
+}
+
+// Vote is the vote of the authenticated user on a Thing.
+type Vote int
+
+// NoVote, Upvote and Downvote are the possible values of a Vote.
+const (
+	NoVote   Vote = 0
+	Upvote   Vote = 1
+	Downvote Vote = -1
+)
+
+// UnmarshalJSON implements json.Unmarshaler for Vote. reddit sends true for an upvote, false for a
+// downvote and null if the user has not voted.
+func (v *Vote) UnmarshalJSON(b []byte) error {
+	switch string(b) {
+	case "null":
+		*v = NoVote
+	case "true":
+		*v = Upvote
+	case "false":
+		*v = Downvote
+	default:
+		return fmt.Errorf("invalid vote: %s", string(b))
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for Vote. It is the inverse of UnmarshalJSON.
+func (v Vote) MarshalJSON() ([]byte, error) {
+	switch v {
+	case Upvote:
+		return []byte("true"), nil
+	case Downvote:
+		return []byte("false"), nil
+	}
+	return []byte("null"), nil
+}
+
+// Created holds creation time information.
+//
+// See https://github.com/reddit/reddit/wiki/JSON
+type Created struct {
+	Created    Timestamp `json:"created"`
+	CreatedUTC Timestamp `json:"created_utc"`
+}
+
+// Edited denotes the current edit state of a Thing. If Edited is true, Unix will
+// hold the last edited time.
+type Edited struct {
+	Unix   Timestamp
+	Edited bool
+}
+
+// UnmarshalJSON implement json.Unmarshaller for Edited. It expects false if
+// no edits were performed, or a float timestamp of when the last edit was performed.
+func (e *Edited) UnmarshalJSON(b []byte) error {
+	str := string(b)
+	if str == "false" {
+		e.Unix, e.Edited = 0, false
+		return nil
+	}
+	val, err := strconv.ParseFloat(string(b), 64)
+	if err != nil {
+		return err
+	}
+	e.Unix, e.Edited = Timestamp(val), true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for Edited. It returns false if e.Edited is false
+// and a float timestamp if not.
+func (e Edited) MarshalJSON() ([]byte, error) {
+	if e.Edited {
+		return json.Marshal(float64(e.Unix))
+	}
+	return []byte("false"), nil
+}
+
+// Replies holds the replies to a Comment or Message. reddit sends an empty string when there are
+// no replies and a Listing otherwise.
+type Replies []Thing
+
+// UnmarshalJSON implements json.Unmarshaler for Replies. It accepts an empty string, null or a Listing.
+func (r *Replies) UnmarshalJSON(b []byte) error {
+	if s := string(b); s == `""` || s == "null" {
+		*r = nil
+		return nil
+	}
+	var t Thing
+	if err := json.Unmarshal(b, &t); err != nil {
+		return err
+	}
+	l, ok := t.Data.(*Listing)
+	if !ok {
+		return fmt.Errorf("expected Listing for replies, got %s", t.Kind)
+	}
+	*r = l.Children
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for Replies. It is the inverse of UnmarshalJSON.
+func (r Replies) MarshalJSON() ([]byte, error) {
+	if len(r) == 0 {
+		return []byte(`""`), nil
+	}
+	return json.Marshal(Thing{Kind: "Listing", Data: &Listing{Children: r}})
+}
+
+// HeaderSize is a header size for a subreddit.
+type HeaderSize struct {
+	Width  int
+	Height int
+}
+
+// UnmarshalJSON implements json.Unmarshaler for HeaderSize. It converts an array of ints
+// into a HeaderSize.
+func (h *HeaderSize) UnmarshalJSON(b []byte) error {
+	v := []int{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if len(v) == 0 {
+		return nil
+	}
+	if len(v) != 2 {
+		return fmt.Errorf("expected 2 element array, got %d elements (%v)", len(v), v)
+	}
+	h.Width, h.Height = v[0], v[1]
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for HeaderSize. It converts HeaderSize into an array of ints.
+func (h *HeaderSize) MarshalJSON() ([]byte, error) {
+	if h == nil {
+		return []byte("null"), nil
+	}
+	return []byte(fmt.Sprintf("[%d, %d]", h.Width, h.Height)), nil
+}
+
+// Awarding is an award given to a Link or Comment.
+type Awarding struct {
+	AwardType     string        `json:"award_type"`
+	CoinPrice     int           `json:"coin_price"`
+	Count         int           `json:"count"`
+	Description   string        `json:"description"`
+	IconHeight    int           `json:"icon_height"`
+	IconURL       string        `json:"icon_url"`
+	IconWidth     int           `json:"icon_width"`
+	ID            string        `json:"id"`
+	Name          string        `json:"name"`
+	ResizedIcons  []ImageSource `json:"resized_icons"`
+	StaticIconURL string        `json:"static_icon_url"`
+}
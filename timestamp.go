@@ -0,0 +1,64 @@
+package reddit
+
+import (
+	"math"
+	"time"
+)
+
+// Timestamp is a time sent by reddit as (possibly fractional) seconds since the unix epoch. It
+// marshals back to the same float value it was unmarshalled from.
+type Timestamp float64
+
+// NewTimestamp returns the Timestamp for t.
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp(float64(t.Unix()) + float64(t.Nanosecond())/1e9)
+}
+
+// Time returns ts as a time.Time in UTC. The zero Timestamp returns the zero time.Time.
+func (ts Timestamp) Time() time.Time {
+	if ts == 0 {
+		return time.Time{}
+	}
+	sec, frac := math.Modf(float64(ts))
+	return time.Unix(int64(sec), int64(math.Round(frac*1e9))).UTC()
+}
+
+// Age returns the time elapsed since ts according to the system clock. It ignores the clock set with
+// WithClock, use Config.Age for that.
+func (ts Timestamp) Age() time.Duration { return clock.Now().Sub(ts.Time()) }
+
+// Age returns the time elapsed since ts according to the clock of c, see WithClock.
+func (c *Config) Age(ts Timestamp) time.Duration { return c.clock().Now().Sub(ts.Time()) }
+
+// CreatedAt returns the creation time of a Thing. Prefer this to Created, which is in the local
+// time of reddit's servers.
+func (c Created) CreatedAt() time.Time { return c.CreatedUTC.Time() }
+
+// Age returns the time elapsed since the Thing was created according to the system clock. It ignores
+// the clock set with WithClock, use Config.Age(c.CreatedUTC) for that.
+func (c Created) Age() time.Duration { return c.CreatedUTC.Age() }
+
+// CreatedAt returns the time at which the revision was made.
+func (w WikiRevision) CreatedAt() time.Time { return w.Timestamp.Time() }
+
+// Time returns the last edited time, or the zero time.Time if no edits were performed.
+func (e Edited) Time() time.Time {
+	if !e.Edited {
+		return time.Time{}
+	}
+	return e.Unix.Time()
+}
+
+// CreatedAt returns the creation time of the data in t. It returns false if the kind of t does not
+// have a creation time.
+func CreatedAt(t Thing) (time.Time, bool) {
+	switch d := t.Data.(type) {
+	case interface{ CreatedAt() time.Time }:
+		return d.CreatedAt(), true
+	case *ModAction:
+		return d.CreatedUTC.Time(), true
+	case *LabeledMulti:
+		return d.CreatedUTC.Time(), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,63 @@
+package reddit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimestamp(t *testing.T) {
+	require := require.New(t)
+
+	ts := Timestamp(1461792000.25)
+	require.Equal(time.Date(2016, 4, 27, 21, 20, 0, 250000000, time.UTC), ts.Time())
+	require.Equal(ts, NewTimestamp(ts.Time()))
+	require.True(Timestamp(0).Time().IsZero())
+
+	var e Edited
+	require.NoError(json.Unmarshal([]byte("1461792000.25"), &e))
+	require.Equal(ts.Time(), e.Time())
+	data, err := json.Marshal(e)
+	require.NoError(err)
+	require.Equal("1461792000.25", string(data))
+	require.True(Edited{}.Time().IsZero())
+
+	m := mock()
+	defer m.reset()
+	l := Link{Created: Created{CreatedUTC: NewTimestamp(m.time.Add(-time.Hour))}}
+	require.Equal(time.Hour, l.Age().Round(time.Millisecond))
+	cfg := (&Config{}).Configure(WithClock(clockwork.NewFakeClockAt(m.time.Add(time.Hour))))
+	require.Equal(2*time.Hour, cfg.Age(l.CreatedUTC).Round(time.Millisecond))
+	created, ok := CreatedAt(Thing{Data: &l})
+	require.True(ok)
+	require.Equal(l.CreatedAt(), created)
+	_, ok = CreatedAt(Thing{Data: &Listing{}})
+	require.False(ok)
+	require.Equal(ts.Time(), WikiRevision{Timestamp: ts}.CreatedAt())
+}
+
+const newLinksBody = `{"kind": "Listing", "data": {"after": "t3_c", "children": [
+	{"kind": "t3", "data": {"id": "a", "created_utc": 300}},
+	{"kind": "t3", "data": {"id": "b", "created_utc": 200}},
+	{"kind": "t3", "data": {"id": "c", "created_utc": 100}}
+]}}`
+
+func TestStream_Since(t *testing.T) {
+	m := mock(get("https://oauth.reddit.com/r/golang/new.json?limit=3", newLinksBody))
+	defer m.reset()
+
+	require := require.New(t)
+	stream := authedConfig(m).Stream(nil, &SubRedditPosts{
+		SubReddit: "golang", Sort: LinksNew, ListingOptions: ListingOptions{Limit: 3},
+	}).Since(time.Unix(150, 0))
+	var ids []string
+	for stream.Next() {
+		ids = append(ids, stream.Thing().ID)
+	}
+	require.NoError(stream.Error())
+	require.Equal([]string{"a", "b"}, ids)
+	require.Equal(Thing{}, stream.Thing())
+}
@@ -1,243 +1,218 @@
+// This file is maintained by hand. Running go generate adds the fields found in captured reddit responses
+// with reddit-gen. Existing field names, types, tags and comments are kept, so edit them here to override
+// what reddit-gen would infer.
+
 package reddit
 
 import (
 	"encoding/json"
-	"fmt"
-	"strconv"
 )
 
-// Thing holds attributes common to all reddit api entities.
-//
-// See https://github.com/reddit/reddit/wiki/JSON
-type Thing struct {
-	ID   string      `json:"id"`
-	Name string      `json:"name"`
-	Kind string      `json:"kind"`
-	Data interface{} `json:"data"`
-}
-
-type thingJSON struct {
-	ID   string          `json:"id"`
-	Name string          `json:"name"`
-	Kind string          `json:"kind"`
-	Data json.RawMessage `json:"data"`
-}
-
-// UnmarshalJSON implements json.Unmarshaller for Thing. It performs this in two passes. In the
-// first pass the data is left unmarshalled. The value of kind is then used to determine the struct type
-// for Data.
-func (t *Thing) UnmarshalJSON(b []byte) error {
-	var j thingJSON
-	if err := json.Unmarshal(b, &j); err != nil {
-		return err
-	}
-	var val interface{}
-	switch j.Kind {
-	case "Listing":
-		val = &Listing{}
-	case "t1":
-		val = &Comment{}
-	case "t2":
-		val = &Account{}
-	case "t3":
-		val = &Link{}
-	case "t4":
-		val = &Message{}
-	case "t5":
-		val = &SubReddit{}
-	default:
-		return fmt.Errorf("unsupported kind: %s", j.Kind)
-	}
-	if err := json.Unmarshal(j.Data, val); err != nil {
-		return err
-	}
-	t.ID, t.Name, t.Kind, t.Data  = j.ID, j.Name, j.Kind, val
-	return nil
-}
-
-// Listing contains paginated content from an API request.
-//
-// See https://github.com/reddit/reddit/wiki/JSON
-type Listing struct {
-	Before   string  `json:"before"`
-	After    string  `json:"after"`
-	Modhash  string  `json:"modhash"`
-	Children []Thing `json:"children"`
-}
-
-// Votable holds attributes related to voting.
-//
-// See https://github.com/reddit/reddit/wiki/JSON
-type Votable struct {
-	Ups   int  `json:"ups"`
-	Downs int  `json:"downs"`
-	Likes bool `json:"likes"`
-}
-
-// Created holds creation time information.
-//
-// See https://github.com/reddit/reddit/wiki/JSON
-type Created struct {
-	Created    float64 `json:"created"`
-	CreatedUTC float64 `json:"created_utc"`
-}
-
-// Edited denotes the current edit state of a Thing. If Edited is true, Unix will
-// hold the last edited time as seconds since the unix epoch.
-type Edited struct {
-	Unix   float64
-	Edited bool
-}
-
-// UnmarshalJSON implement json.Unmarshaller for Edited. It expects false if
-// no edits were performed, or a float timestamp of when the last edit was performed.
-func (e *Edited) UnmarshalJSON(b []byte) error {
-	str := string(b)
-	if str == "false" {
-		e.Unix, e.Edited = 0, false
-		return nil
-	}
-	val, err := strconv.ParseFloat(string(b), 64)
-	if err != nil {
-		return err
-	}
-	e.Unix, e.Edited = val, true
-	return nil
-}
-
-// MarshalJSON implements json.Marshaler for Edited. It returns false if e.Edited is false
-// and a float timestamp if not.
-func (e *Edited) MarshalJSON() ([]byte, error) {
-	if e.Edited {
-		return []byte(fmt.Sprintf("%.0f", e.Unix)), nil
-	}
-	return []byte("false"), nil
-}
-
 // Comment represents a single reddit comment.
 //
 // See https://github.com/reddit/reddit/wiki/JSON
 type Comment struct {
 	Votable
 	Created
-	ApprovedBy          string  `json:"approved_by"`
-	Author              string  `json:"author"`
-	AuthorFlairCSSClass string  `json:"author_flair_css_class"`
-	AuthorFlairText     string  `json:"author_flair_text"`
-	BannedBy            string  `json:"banned_by"`
-	Body                string  `json:"body"`
-	BodyHTML            string  `json:"body_html"`
-	Edited              Edited  `json:"edited"`
-	Gilded              int     `json:"gilded"`
-	Likes               bool    `json:"likes"`
-	LinkAuthor          string  `json:"link_author"`
-	LinkID              string  `json:"link_id"`
-	LinkTitle           string  `json:"link_title"`
-	LinkURL             string  `json:"link_url"`
-	NumReports          int     `json:"num_reports"`
-	ParentID            string  `json:"parent_id"`
-	Replies             []Thing `json:"replies"`
-	Saved               bool    `json:"saved"`
-	Score               int     `json:"score"`
-	ScoreHidden         bool    `json:"score_hidden"`
-	Subreddit           string  `json:"subreddit"`
-	SubredditID         string  `json:"subreddit_id"`
-	Distinguished       string  `json:"distinguished"`
+	AllAwardings               []Awarding                 `json:"all_awardings"`
+	ApprovedAtUTC              Timestamp                  `json:"approved_at_utc"`
+	ApprovedBy                 string                     `json:"approved_by"`
+	Archived                   bool                       `json:"archived"`
+	Author                     string                     `json:"author"`
+	AuthorFlairBackgroundColor string                     `json:"author_flair_background_color"`
+	AuthorFlairCSSClass        string                     `json:"author_flair_css_class"`
+	AuthorFlairRichtext        FlairRichtext              `json:"author_flair_richtext"`
+	AuthorFlairTemplateID      string                     `json:"author_flair_template_id"`
+	AuthorFlairText            string                     `json:"author_flair_text"`
+	AuthorFlairTextColor       string                     `json:"author_flair_text_color"`
+	AuthorFlairType            string                     `json:"author_flair_type"`
+	AuthorFullname             Fullname                   `json:"author_fullname"`
+	AuthorIsBlocked            bool                       `json:"author_is_blocked"`
+	AuthorPremium              bool                       `json:"author_premium"`
+	BannedAtUTC                Timestamp                  `json:"banned_at_utc"`
+	BannedBy                   string                     `json:"banned_by"`
+	Body                       string                     `json:"body"`
+	BodyHTML                   string                     `json:"body_html"`
+	CanGild                    bool                       `json:"can_gild"`
+	CanModPost                 bool                       `json:"can_mod_post"`
+	Collapsed                  bool                       `json:"collapsed"`
+	CollapsedReason            string                     `json:"collapsed_reason"`
+	Context                    string                     `json:"context"`
+	Controversiality           int                        `json:"controversiality"`
+	Depth                      int                        `json:"depth"`
+	Distinguished              string                     `json:"distinguished"`
+	Edited                     Edited                     `json:"edited"`
+	Gilded                     int                        `json:"gilded"`
+	Gildings                   map[string]int             `json:"gildings"`
+	ID                         string                     `json:"id"`
+	IsSubmitter                bool                       `json:"is_submitter"`
+	LinkAuthor                 string                     `json:"link_author"`
+	LinkID                     Fullname                   `json:"link_id"`
+	LinkTitle                  string                     `json:"link_title"`
+	LinkURL                    string                     `json:"link_url"`
+	Locked                     bool                       `json:"locked"`
+	ModNote                    string                     `json:"mod_note"`
+	ModReasonBy                string                     `json:"mod_reason_by"`
+	ModReasonTitle             string                     `json:"mod_reason_title"`
+	Name                       Fullname                   `json:"name"`
+	New                        bool                       `json:"new"`
+	NumReports                 int                        `json:"num_reports"`
+	ParentID                   Fullname                   `json:"parent_id"`
+	Permalink                  string                     `json:"permalink"`
+	RemovalReason              string                     `json:"removal_reason"`
+	Replies                    Replies                    `json:"replies"`
+	Saved                      bool                       `json:"saved"`
+	Score                      int                        `json:"score"`
+	ScoreHidden                bool                       `json:"score_hidden"`
+	SendReplies                bool                       `json:"send_replies"`
+	Stickied                   bool                       `json:"stickied"`
+	Subject                    string                     `json:"subject"`
+	Subreddit                  string                     `json:"subreddit"`
+	SubredditID                Fullname                   `json:"subreddit_id"`
+	SubredditNamePrefixed      string                     `json:"subreddit_name_prefixed"`
+	SubredditType              string                     `json:"subreddit_type"`
+	TotalAwardsReceived        int                        `json:"total_awards_received"`
+	WasComment                 bool                       `json:"was_comment"`
+	Extra                      map[string]json.RawMessage `json:"-"` // Fields sent by reddit that are not present in the struct.
 }
 
-// Link represents a single link on reddit.
+// Account represents a single account on reddit.
 //
 // See https://github.com/reddit/reddit/wiki/JSON
-type Link struct {
-	Votable
+type Account struct {
 	Created
-	Author              string          `json:"author"`
-	AuthorFlairCSSClass string          `json:"author_flair_css_class"`
-	AuthorFlairText     string          `json:"author_flair_text"`
-	Clicked             bool            `json:"clicked"`
-	Domain              string          `json:"domain"`
-	Hidden              bool            `json:"hidden"`
-	IsSelf              bool            `json:"is_self"`
-	Likes               bool            `json:"likes"`
-	LinkFlairCSSClass   string          `json:"link_flair_css_class"`
-	LinkFlairText       string          `json:"link_flair_text"`
-	Locked              bool            `json:"locked"`
-	Media               json.RawMessage `json:"media"`
-	MediaEmbed          json.RawMessage `json:"media_embed"`
-	NumComments         int             `json:"num_comments"`
-	Over18              bool            `json:"over_18"`
-	Permalink           string          `json:"permalink"`
-	Saved               bool            `json:"saved"`
-	Score               int             `json:"score"`
-	Selftext            string          `json:"selftext"`
-	SelftextHTML        string          `json:"selftext_html"`
-	Subreddit           string          `json:"subreddit"`
-	SubredditID         string          `json:"subreddit_id"`
-	Thumbnail           string          `json:"thumbnail"`
-	Title               string          `json:"title"`
-	URL                 string          `json:"url"`
-	Edited              Edited          `json:"edited"`
-	Distinguished       string          `json:"distinguished"`
-	Stickied            bool            `json:"stickied"`
-}
-
-// HeaderSize is a header size for a subreddit.
-type HeaderSize struct {
-	Width  int
-	Height int
-}
-
-// UnmarshalJSON implements json.Unmarshaler for HeaderSize. It converts an array of ints
-// into a HeaderSize.
-func (h *HeaderSize) UnmarshalJSON(b []byte) error {
-	v := []int{}
-	if err := json.Unmarshal(b, &v); err != nil {
-		return err
-	}
-	if len(v) == 0 {
-		return nil
-	}
-	if len(v) != 2 {
-		return fmt.Errorf("expected 2 element array, got %d elements (%v)", len(v), v)
-	}
-	h.Width, h.Height = v[0], v[1]
-	return nil
+	AcceptFollowers  bool                       `json:"accept_followers"`
+	AwardeeKarma     int                        `json:"awardee_karma"`
+	AwarderKarma     int                        `json:"awarder_karma"`
+	CommentKarma     int                        `json:"comment_karma"`
+	HasMail          bool                       `json:"has_mail"`
+	HasModMail       bool                       `json:"has_mod_mail"`
+	HasSubscribed    bool                       `json:"has_subscribed"`
+	HasVerifiedEmail bool                       `json:"has_verified_email"`
+	HideFromRobots   bool                       `json:"hide_from_robots"`
+	IconImg          string                     `json:"icon_img"`
+	ID               string                     `json:"id"`
+	InboxCount       int                        `json:"inbox_count"`
+	IsEmployee       bool                       `json:"is_employee"`
+	IsFriend         bool                       `json:"is_friend"`
+	IsGold           bool                       `json:"is_gold"`
+	IsMod            bool                       `json:"is_mod"`
+	IsSuspended      bool                       `json:"is_suspended"`
+	LinkKarma        int                        `json:"link_karma"`
+	Modhash          string                     `json:"modhash"`
+	Name             string                     `json:"name"`
+	Over18           bool                       `json:"over_18"`
+	Subreddit        *SubReddit                 `json:"subreddit"`
+	TotalKarma       int                        `json:"total_karma"`
+	Verified         bool                       `json:"verified"`
+	Extra            map[string]json.RawMessage `json:"-"` // Fields sent by reddit that are not present in the struct.
 }
 
-// MarshalJSON implements json.Marshaler for HeaderSize. It converts HeaderSize into an array of ints.
-func (h *HeaderSize) MarshalJSON() ([]byte, error) {
-	if h == nil {
-		return []byte("null"), nil
-	}
-	return []byte(fmt.Sprintf("[%d, %d]", h.Width, h.Height)), nil
-}
-
-// SubReddit represents a single subreddit.
+// Link represents a single link on reddit.
 //
 // See https://github.com/reddit/reddit/wiki/JSON
-type SubReddit struct {
-	AccountsActive       int         `json:"accounts_active"`
-	CommentScoreHideMins int         `json:"comment_score_hide_mins"`
-	Description          string      `json:"description"`
-	DescriptionHTML      string      `json:"description_html"`
-	DisplayName          string      `json:"display_name"`
-	HeaderImg            string      `json:"header_img"`
-	HeaderSize           *HeaderSize `json:"header_size"`
-	HeaderTitle          string      `json:"header_title"`
-	Over18               bool        `json:"over18"`
-	PublicDescription    string      `json:"public_description"`
-	PublicTraffic        bool        `json:"public_traffic"`
-	Subscribers          int64       `json:"subscribers"`
-	SubmissionType       string      `json:"submission_type"`
-	SubmitLinkLabel      string      `json:"submit_link_label"`
-	SubmitTextLabel      string      `json:"submit_text_label"`
-	SubredditType        string      `json:"subreddit_type"`
-	Title                string      `json:"title"`
-	URL                  string      `json:"url"`
-	UserIsBanned         bool        `json:"user_is_banned"`
-	UserIsContributor    bool        `json:"user_is_contributor"`
-	UserIsModerator      bool        `json:"user_is_moderator"`
-	UserIsSubscriber     bool        `json:"user_is_subscriber"`
+type Link struct {
+	Votable
+	Created
+	AllAwardings               []Awarding                 `json:"all_awardings"`
+	AllowLiveComments          bool                       `json:"allow_live_comments"`
+	ApprovedAtUTC              Timestamp                  `json:"approved_at_utc"`
+	ApprovedBy                 string                     `json:"approved_by"`
+	Archived                   bool                       `json:"archived"`
+	Author                     string                     `json:"author"`
+	AuthorFlairBackgroundColor string                     `json:"author_flair_background_color"`
+	AuthorFlairCSSClass        string                     `json:"author_flair_css_class"`
+	AuthorFlairRichtext        FlairRichtext              `json:"author_flair_richtext"`
+	AuthorFlairTemplateID      string                     `json:"author_flair_template_id"`
+	AuthorFlairText            string                     `json:"author_flair_text"`
+	AuthorFlairTextColor       string                     `json:"author_flair_text_color"`
+	AuthorFlairType            string                     `json:"author_flair_type"`
+	AuthorFullname             Fullname                   `json:"author_fullname"`
+	AuthorIsBlocked            bool                       `json:"author_is_blocked"`
+	AuthorPremium              bool                       `json:"author_premium"`
+	BannedAtUTC                Timestamp                  `json:"banned_at_utc"`
+	BannedBy                   string                     `json:"banned_by"`
+	CanGild                    bool                       `json:"can_gild"`
+	CanModPost                 bool                       `json:"can_mod_post"`
+	Category                   string                     `json:"category"`
+	Clicked                    bool                       `json:"clicked"`
+	ContestMode                bool                       `json:"contest_mode"`
+	CrosspostParent            Fullname                   `json:"crosspost_parent"`
+	CrosspostParentList        []Link                     `json:"crosspost_parent_list"`
+	Distinguished              string                     `json:"distinguished"`
+	Domain                     string                     `json:"domain"`
+	Edited                     Edited                     `json:"edited"`
+	GalleryData                *GalleryData               `json:"gallery_data"`
+	Gilded                     int                        `json:"gilded"`
+	Gildings                   map[string]int             `json:"gildings"`
+	Hidden                     bool                       `json:"hidden"`
+	HideScore                  bool                       `json:"hide_score"`
+	ID                         string                     `json:"id"`
+	IsCrosspostable            bool                       `json:"is_crosspostable"`
+	IsGallery                  bool                       `json:"is_gallery"`
+	IsMeta                     bool                       `json:"is_meta"`
+	IsOriginalContent          bool                       `json:"is_original_content"`
+	IsRedditMediaDomain        bool                       `json:"is_reddit_media_domain"`
+	IsRobotIndexable           bool                       `json:"is_robot_indexable"`
+	IsSelf                     bool                       `json:"is_self"`
+	IsVideo                    bool                       `json:"is_video"`
+	LinkFlairBackgroundColor   string                     `json:"link_flair_background_color"`
+	LinkFlairCSSClass          string                     `json:"link_flair_css_class"`
+	LinkFlairRichtext          FlairRichtext              `json:"link_flair_richtext"`
+	LinkFlairTemplateID        string                     `json:"link_flair_template_id"`
+	LinkFlairText              string                     `json:"link_flair_text"`
+	LinkFlairTextColor         string                     `json:"link_flair_text_color"`
+	LinkFlairType              string                     `json:"link_flair_type"`
+	Locked                     bool                       `json:"locked"`
+	Media                      *Media                     `json:"media"`
+	MediaEmbed                 MediaEmbed                 `json:"media_embed"`
+	MediaMetadata              map[string]MediaItem       `json:"media_metadata"`
+	ModNote                    string                     `json:"mod_note"`
+	ModReasonBy                string                     `json:"mod_reason_by"`
+	ModReasonTitle             string                     `json:"mod_reason_title"`
+	Name                       Fullname                   `json:"name"`
+	NoFollow                   bool                       `json:"no_follow"`
+	NumComments                int                        `json:"num_comments"`
+	NumCrossposts              int                        `json:"num_crossposts"`
+	NumDuplicates              int                        `json:"num_duplicates"`
+	NumReports                 int                        `json:"num_reports"`
+	Over18                     bool                       `json:"over_18"`
+	ParentWhitelistStatus      string                     `json:"parent_whitelist_status"`
+	Permalink                  string                     `json:"permalink"`
+	Pinned                     bool                       `json:"pinned"`
+	PollData                   *PollData                  `json:"poll_data"`
+	PostHint                   string                     `json:"post_hint"`
+	Preview                    *Preview                   `json:"preview"`
+	Quarantine                 bool                       `json:"quarantine"`
+	RemovalReason              string                     `json:"removal_reason"`
+	RemovedBy                  string                     `json:"removed_by"`
+	RemovedByCategory          string                     `json:"removed_by_category"`
+	Saved                      bool                       `json:"saved"`
+	Score                      int                        `json:"score"`
+	SecureMedia                *Media                     `json:"secure_media"`
+	SecureMediaEmbed           MediaEmbed                 `json:"secure_media_embed"`
+	Selftext                   string                     `json:"selftext"`
+	SelftextHTML               string                     `json:"selftext_html"`
+	SendReplies                bool                       `json:"send_replies"`
+	Spoiler                    bool                       `json:"spoiler"`
+	Stickied                   bool                       `json:"stickied"`
+	Subreddit                  string                     `json:"subreddit"`
+	SubredditID                Fullname                   `json:"subreddit_id"`
+	SubredditNamePrefixed      string                     `json:"subreddit_name_prefixed"`
+	SubredditSubscribers       int64                      `json:"subreddit_subscribers"`
+	SubredditType              string                     `json:"subreddit_type"`
+	SuggestedSort              string                     `json:"suggested_sort"`
+	Thumbnail                  string                     `json:"thumbnail"`
+	ThumbnailHeight            int                        `json:"thumbnail_height"`
+	ThumbnailWidth             int                        `json:"thumbnail_width"`
+	Title                      string                     `json:"title"`
+	TotalAwardsReceived        int                        `json:"total_awards_received"`
+	UpvoteRatio                float64                    `json:"upvote_ratio"`
+	URL                        string                     `json:"url"`
+	ViewCount                  int                        `json:"view_count"`
+	Visited                    bool                       `json:"visited"`
+	WhitelistStatus            string                     `json:"whitelist_status"`
+	Extra                      map[string]json.RawMessage `json:"-"` // Fields sent by reddit that are not present in the struct.
 }
 
 // Message represents a single message on reddit.
@@ -245,41 +220,83 @@ type SubReddit struct {
 // See https://github.com/reddit/reddit/wiki/JSON
 type Message struct {
 	Created
-	Author           string `json:"author"`
-	Body             string `json:"body"`
-	BodyHTML         string `json:"body_html"`
-	Context          string `json:"context"`
-	FirstMessage     string `json:"first_message"`
-	FirstMessageName string `json:"first_message_name"`
-	Likes            bool   `json:"likes"`
-	LinkTitle        string `json:"link_title"`
-	Name             string `json:"name"`
-	New              bool   `json:"new"`
-	ParentID         string `json:"parent_id"`
-	Replies          string `json:"replies"`
-	Subject          string `json:"subject"`
-	Subreddit        string `json:"subreddit"`
-	WasComment       bool   `json:"was_comment"`
+	Author           string                     `json:"author"`
+	AuthorFullname   Fullname                   `json:"author_fullname"`
+	Body             string                     `json:"body"`
+	BodyHTML         string                     `json:"body_html"`
+	Context          string                     `json:"context"`
+	Dest             string                     `json:"dest"`
+	Distinguished    string                     `json:"distinguished"`
+	FirstMessage     int64                      `json:"first_message"`
+	FirstMessageName Fullname                   `json:"first_message_name"`
+	ID               string                     `json:"id"`
+	Likes            Vote                       `json:"likes"`
+	LinkTitle        string                     `json:"link_title"`
+	Name             Fullname                   `json:"name"`
+	New              bool                       `json:"new"`
+	ParentID         Fullname                   `json:"parent_id"`
+	Replies          Replies                    `json:"replies"`
+	Subject          string                     `json:"subject"`
+	Subreddit        string                     `json:"subreddit"`
+	Type             string                     `json:"type"`
+	WasComment       bool                       `json:"was_comment"`
+	Extra            map[string]json.RawMessage `json:"-"` // Fields sent by reddit that are not present in the struct.
 }
 
-// Account represents a single account on reddit.
+// SubReddit represents a single subreddit.
 //
 // See https://github.com/reddit/reddit/wiki/JSON
-type Account struct {
+type SubReddit struct {
 	Created
-	CommentKarma     int    `json:"comment_karma"`
-	HasMail          bool   `json:"has_mail"`
-	HasModMail       bool   `json:"has_mod_mail"`
-	HasVerifiedEmail bool   `json:"has_verified_email"`
-	ID               string `json:"id"`
-	InboxCount       int    `json:"inbox_count"`
-	IsFriend         bool   `json:"is_friend"`
-	IsGold           bool   `json:"is_gold"`
-	IsMod            bool   `json:"is_mod"`
-	LinkKarma        int    `json:"link_karma"`
-	Modhash          string `json:"modhash"`
-	Name             string `json:"name"`
-	Over18           bool   `json:"over_18"`
+	AccountsActive        int                        `json:"accounts_active"`
+	ActiveUserCount       int                        `json:"active_user_count"`
+	AllowGalleries        bool                       `json:"allow_galleries"`
+	AllowImages           bool                       `json:"allow_images"`
+	AllowPolls            bool                       `json:"allow_polls"`
+	AllowVideos           bool                       `json:"allow_videos"`
+	BannerBackgroundColor string                     `json:"banner_background_color"`
+	BannerBackgroundImage string                     `json:"banner_background_image"`
+	BannerImg             string                     `json:"banner_img"`
+	BannerSize            *HeaderSize                `json:"banner_size"`
+	CommentScoreHideMins  int                        `json:"comment_score_hide_mins"`
+	CommunityIcon         string                     `json:"community_icon"`
+	Description           string                     `json:"description"`
+	DescriptionHTML       string                     `json:"description_html"`
+	DisplayName           string                     `json:"display_name"`
+	DisplayNamePrefixed   string                     `json:"display_name_prefixed"`
+	HeaderImg             string                     `json:"header_img"`
+	HeaderSize            *HeaderSize                `json:"header_size"`
+	HeaderTitle           string                     `json:"header_title"`
+	IconImg               string                     `json:"icon_img"`
+	IconSize              *HeaderSize                `json:"icon_size"`
+	ID                    string                     `json:"id"`
+	KeyColor              string                     `json:"key_color"`
+	Lang                  string                     `json:"lang"`
+	Name                  Fullname                   `json:"name"`
+	Over18                bool                       `json:"over18"`
+	PrimaryColor          string                     `json:"primary_color"`
+	PublicDescription     string                     `json:"public_description"`
+	PublicDescriptionHTML string                     `json:"public_description_html"`
+	PublicTraffic         bool                       `json:"public_traffic"`
+	Quarantine            bool                       `json:"quarantine"`
+	SpoilersEnabled       bool                       `json:"spoilers_enabled"`
+	SubmissionType        string                     `json:"submission_type"`
+	SubmitLinkLabel       string                     `json:"submit_link_label"`
+	SubmitText            string                     `json:"submit_text"`
+	SubmitTextHTML        string                     `json:"submit_text_html"`
+	SubmitTextLabel       string                     `json:"submit_text_label"`
+	SubredditType         string                     `json:"subreddit_type"`
+	Subscribers           int64                      `json:"subscribers"`
+	Title                 string                     `json:"title"`
+	URL                   string                     `json:"url"`
+	UserHasFavorited      bool                       `json:"user_has_favorited"`
+	UserIsBanned          bool                       `json:"user_is_banned"`
+	UserIsContributor     bool                       `json:"user_is_contributor"`
+	UserIsModerator       bool                       `json:"user_is_moderator"`
+	UserIsMuted           bool                       `json:"user_is_muted"`
+	UserIsSubscriber      bool                       `json:"user_is_subscriber"`
+	WikiEnabled           bool                       `json:"wiki_enabled"`
+	Extra                 map[string]json.RawMessage `json:"-"` // Fields sent by reddit that are not present in the struct.
 }
 
 // More holds a list of Thing IDs that are present but not included in full in a response.
@@ -287,4 +304,109 @@ type Account struct {
 // See https://github.com/reddit/reddit/wiki/JSON
 type More struct {
 	Children []string `json:"children"`
+	Count    int      `json:"count"`
+	Depth    int      `json:"depth"`
+	ID       string   `json:"id"`
+	Name     Fullname `json:"name"`
+	ParentID Fullname `json:"parent_id"`
+}
+
+// ModAction represents a single entry in the moderation log of a subreddit.
+type ModAction struct {
+	Action          string    `json:"action"`
+	CreatedUTC      Timestamp `json:"created_utc"`
+	Description     string    `json:"description"`
+	Details         string    `json:"details"`
+	ID              string    `json:"id"`
+	Mod             string    `json:"mod"`
+	ModID36         string    `json:"mod_id36"`
+	SrID36          string    `json:"sr_id36"`
+	Subreddit       string    `json:"subreddit"`
+	TargetAuthor    string    `json:"target_author"`
+	TargetBody      string    `json:"target_body"`
+	TargetFullname  Fullname  `json:"target_fullname"`
+	TargetPermalink string    `json:"target_permalink"`
+	TargetTitle     string    `json:"target_title"`
+}
+
+// WikiPage represents a single revision of a subreddit wiki page.
+type WikiPage struct {
+	ContentHTML  string    `json:"content_html"`
+	ContentMD    string    `json:"content_md"`
+	MayRevise    bool      `json:"may_revise"`
+	Reason       string    `json:"reason"`
+	RevisionBy   *Thing    `json:"revision_by"`
+	RevisionDate Timestamp `json:"revision_date"`
+	RevisionID   string    `json:"revision_id"`
+}
+
+// WikiPageSettings holds the permission settings of a subreddit wiki page. Editors holds an Account Thing
+// for each user allowed to edit the page.
+type WikiPageSettings struct {
+	Editors   []Thing       `json:"editors"`
+	Listed    bool          `json:"listed"`
+	PermLevel WikiPermLevel `json:"permlevel"`
+}
+
+// SubRedditSettings holds the settings of a subreddit as returned by /r/{subreddit}/about/edit. The url tags
+// are the names used when saving the settings with /api/site_admin.
+type SubRedditSettings struct {
+	AllowImages               bool     `json:"allow_images" url:"allow_images"`
+	AllowPolls                bool     `json:"allow_polls" url:"allow_polls"`
+	AllowVideos               bool     `json:"allow_videos" url:"allow_videos"`
+	CollapseDeletedComments   bool     `json:"collapse_deleted_comments" url:"collapse_deleted_comments"`
+	CommentScoreHideMins      int      `json:"comment_score_hide_mins" url:"comment_score_hide_mins"`
+	ContentOptions            string   `json:"content_options" url:"link_type"` // any, link or self
+	Description               string   `json:"description" url:"description"`
+	ExcludeBannedModqueue     bool     `json:"exclude_banned_modqueue" url:"exclude_banned_modqueue"`
+	FreeFormReports           bool     `json:"free_form_reports" url:"free_form_reports"`
+	HeaderHoverText           string   `json:"header_hover_text" url:"header-title"`
+	HideAds                   bool     `json:"hide_ads" url:"hide_ads"`
+	KeyColor                  string   `json:"key_color" url:"key_color"`
+	Language                  string   `json:"language" url:"lang"`
+	OriginalContentTagEnabled bool     `json:"original_content_tag_enabled" url:"original_content_tag_enabled"`
+	Over18                    bool     `json:"over_18" url:"over_18"`
+	PublicDescription         string   `json:"public_description" url:"public_description"`
+	RestrictCommenting        bool     `json:"restrict_commenting" url:"restrict_commenting"`
+	RestrictPosting           bool     `json:"restrict_posting" url:"restrict_posting"`
+	ShowMedia                 bool     `json:"show_media" url:"show_media"`
+	ShowMediaPreview          bool     `json:"show_media_preview" url:"show_media_preview"`
+	SpamComments              string   `json:"spam_comments" url:"spam_comments"`   // low, high or all
+	SpamLinks                 string   `json:"spam_links" url:"spam_links"`         // low, high or all
+	SpamSelfposts             string   `json:"spam_selfposts" url:"spam_selfposts"` // low, high or all
+	SpoilersEnabled           bool     `json:"spoilers_enabled" url:"spoilers_enabled"`
+	SubmitLinkLabel           string   `json:"submit_link_label" url:"submit_link_label"`
+	SubmitText                string   `json:"submit_text" url:"submit_text"`
+	SubmitTextLabel           string   `json:"submit_text_label" url:"submit_text_label"`
+	SubredditID               Fullname `json:"subreddit_id" url:"sr"`
+	SubredditType             string   `json:"subreddit_type" url:"type"` // public, private, restricted, etc
+	SuggestedCommentSort      string   `json:"suggested_comment_sort" url:"suggested_comment_sort,omitempty"`
+	Title                     string   `json:"title" url:"title"`
+	WelcomeMessageEnabled     bool     `json:"welcome_message_enabled" url:"welcome_message_enabled"`
+	WelcomeMessageText        string   `json:"welcome_message_text" url:"welcome_message_text"`
+	WikiEditAge               int      `json:"wiki_edit_age" url:"wiki_edit_age"`
+	WikiEditKarma             int      `json:"wiki_edit_karma" url:"wiki_edit_karma"`
+	WikiMode                  string   `json:"wikimode" url:"wikimode"` // disabled, modonly or anyone
+}
+
+// LabeledMulti represents a single multireddit.
+type LabeledMulti struct {
+	CanEdit         bool             `json:"can_edit"`
+	CopiedFrom      string           `json:"copied_from"`
+	CreatedUTC      Timestamp        `json:"created_utc"`
+	DescriptionHTML string           `json:"description_html"`
+	DescriptionMD   string           `json:"description_md"`
+	DisplayName     string           `json:"display_name"`
+	IconURL         string           `json:"icon_url"`
+	IsFavorited     bool             `json:"is_favorited"`
+	IsSubscriber    bool             `json:"is_subscriber"`
+	KeyColor        string           `json:"key_color"`
+	Name            string           `json:"name"`
+	NumSubscribers  int              `json:"num_subscribers"`
+	Over18          bool             `json:"over_18"`
+	Owner           string           `json:"owner"`
+	OwnerID         Fullname         `json:"owner_id"`
+	Path            string           `json:"path"`
+	Subreddits      []MultiSubReddit `json:"subreddits"`
+	Visibility      string           `json:"visibility"`
 }
@@ -0,0 +1,180 @@
+package reddit
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readFixture(t *testing.T, name string, val interface{}) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, val))
+}
+
+func requireRoundTrip(t *testing.T, val, empty interface{}) {
+	data, err := json.Marshal(val)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, empty))
+	require.Equal(t, val, empty)
+}
+
+func TestDecode_Links(t *testing.T) {
+	require := require.New(t)
+
+	var thing Thing
+	readFixture(t, "links.json", &thing)
+	listing := thing.Data.(*Listing)
+	require.Equal("t3_4h3p2k", listing.After)
+	require.Len(listing.Children, 2)
+
+	sticky := listing.Children[0].Data.(*Link)
+	require.Equal(NoVote, sticky.Likes)
+	require.False(sticky.Edited.Edited)
+	require.Equal("moderator", sticky.Distinguished)
+	require.Equal(Fullname("t5_2rc7j"), sticky.SubredditID)
+
+	release := listing.Children[1].Data.(*Link)
+	require.Equal(Upvote, release.Likes)
+	require.Equal(Edited{Unix: 1461792000, Edited: true}, release.Edited)
+	require.Equal(Fullname("t3_4h3p2k"), listing.Children[1].Name)
+
+	requireRoundTrip(t, &thing, &Thing{})
+}
+
+func TestDecode_Comments(t *testing.T) {
+	require := require.New(t)
+
+	var things []Thing
+	readFixture(t, "comments.json", &things)
+	require.Len(things, 2)
+	require.Equal(Downvote, things[0].Data.(*Listing).Children[0].Data.(*Link).Likes)
+
+	top := things[1].Data.(*Listing).Children
+	require.Len(top, 1)
+	parent := top[0].Data.(*Comment)
+	require.Equal(Upvote, parent.Likes)
+	require.Len(parent.Replies, 2)
+
+	reply := parent.Replies[0].Data.(*Comment)
+	require.Equal(Downvote, reply.Likes)
+	require.Nil(reply.Replies)
+	require.Equal(parent.Name, reply.ParentID)
+
+	more := parent.Replies[1].Data.(*More)
+	require.Equal([]string{"d2nc0aa"}, more.Children)
+	require.Equal(parent.Name, more.ParentID)
+
+	requireRoundTrip(t, &things, &[]Thing{})
+}
+
+func TestDecode_Inbox(t *testing.T) {
+	require := require.New(t)
+
+	var thing Thing
+	readFixture(t, "inbox.json", &thing)
+	children := thing.Data.(*Listing).Children
+	require.Len(children, 2)
+
+	msg := children[0].Data.(*Message)
+	require.Equal(NoVote, msg.Likes)
+	require.Nil(msg.Replies)
+	require.Equal(int64(59201472), msg.FirstMessage)
+	require.Equal(Fullname("t4_z8h1g0"), msg.ParentID)
+
+	reply := children[1].Data.(*Comment)
+	require.Equal(Fullname("t1_d2nbx1a"), reply.ParentID)
+
+	requireRoundTrip(t, &thing, &Thing{})
+}
+
+func TestDecode_SubReddit(t *testing.T) {
+	require := require.New(t)
+
+	var thing Thing
+	readFixture(t, "subreddit_about.json", &thing)
+	sub := thing.Data.(*SubReddit)
+	require.Equal("golang", sub.DisplayName)
+	require.Equal(&HeaderSize{Width: 160, Height: 64}, sub.HeaderSize)
+	require.Equal(int64(187366), sub.Subscribers)
+
+	requireRoundTrip(t, &thing, &Thing{})
+}
+
+func TestVote_JSON(t *testing.T) {
+	require := require.New(t)
+
+	for _, v := range []Vote{NoVote, Upvote, Downvote} {
+		var got Vote
+		data, err := json.Marshal(v)
+		require.NoError(err)
+		require.NoError(json.Unmarshal(data, &got))
+		require.Equal(v, got)
+	}
+	var v Vote
+	require.EqualError(json.Unmarshal([]byte(`1`), &v), "invalid vote: 1")
+}
+
+const extraLink = `{"kind": "t3", "data": {
+	"id": "4h3p2k", "upvote_ratio": 0.97, "num_crossposts": 2, "removed_by_category": "moderator",
+	"author_fullname": "t2_1w72", "is_video": false, "total_awards_received": 1,
+	"all_awardings": [{"id": "gid_1", "name": "Silver", "count": 1, "coin_price": 100}],
+	"subreddit_name_prefixed": "r/golang", "is_original_content": true,
+	"brand_new_field": {"nested": [1, 2, 3]}, "another_one": "x"
+}}`
+
+func TestDecode_Extra(t *testing.T) {
+	require := require.New(t)
+
+	var thing Thing
+	require.NoError(json.Unmarshal([]byte(extraLink), &thing))
+	l := thing.Data.(*Link)
+	require.Equal(0.97, l.UpvoteRatio)
+	require.Equal(2, l.NumCrossposts)
+	require.Equal("moderator", l.RemovedByCategory)
+	require.Equal(Fullname("t2_1w72"), l.AuthorFullname)
+	require.Equal([]Awarding{{ID: "gid_1", Name: "Silver", Count: 1, CoinPrice: 100}}, l.AllAwardings)
+	require.Equal("r/golang", l.SubredditNamePrefixed)
+	require.True(l.IsOriginalContent)
+	require.Equal(map[string]json.RawMessage{
+		"brand_new_field": json.RawMessage(`{"nested":[1,2,3]}`),
+		"another_one":     json.RawMessage(`"x"`),
+	}, l.Extra)
+
+	data, err := json.Marshal(l)
+	require.NoError(err)
+	var fields map[string]interface{}
+	require.NoError(json.Unmarshal(data, &fields))
+	require.Equal("x", fields["another_one"])
+
+	requireRoundTrip(t, &thing, &Thing{})
+}
+
+func TestDecode_ExtraDirect(t *testing.T) {
+	require := require.New(t)
+
+	var l Link
+	require.NoError(json.Unmarshal([]byte(`{"id": "abc", "title": "Crossposted", "new_field": 1,
+		"crosspost_parent_list": [{"id": "def", "title": "Original", "parent_field": {"a": true}}]}`), &l))
+	require.Equal("Crossposted", l.Title)
+	require.Equal(map[string]json.RawMessage{"new_field": json.RawMessage(`1`)}, l.Extra)
+	require.Len(l.CrosspostParentList, 1)
+	require.Equal("Original", l.CrosspostParentList[0].Title)
+	require.Equal(map[string]json.RawMessage{"parent_field": json.RawMessage(`{"a":true}`)}, l.CrosspostParentList[0].Extra)
+
+	var c Comment
+	require.NoError(json.Unmarshal([]byte(`{"id": "ghi", "body": "Hi", "new_field": "x"}`), &c))
+	require.Equal(map[string]json.RawMessage{"new_field": json.RawMessage(`"x"`)}, c.Extra)
+
+	data, err := json.Marshal(l)
+	require.NoError(err)
+	var decoded Link
+	require.NoError(json.Unmarshal(data, &decoded))
+	require.Equal(l, decoded)
+
+	var bad Link
+	require.EqualError(json.Unmarshal([]byte(`{"title": 1}`), &bad), "json: cannot unmarshal number into Go struct field Link.title of type string")
+}
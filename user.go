@@ -0,0 +1,58 @@
+package reddit
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-querystring/query"
+)
+
+// Account fetches information about the user with the provided name.
+func (c *Config) Account(client *http.Client, user string) (*Account, error) {
+	u := fmt.Sprintf("%s/user/%s/about.json", RedditAPIURL, user)
+	var t Thing
+	if err := c.Get(client, u, &t); err != nil {
+		return nil, err
+	}
+	a, ok := t.Data.(*Account)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %s response from %s", t.Kind, u)
+	}
+	return a, nil
+}
+
+// UserWhere selects one of the listings of a user's activity.
+type UserWhere string
+
+// UserOverview, UserSubmitted and UserComments are the supported listings for UserListing.
+const (
+	UserOverview  UserWhere = "overview"
+	UserSubmitted UserWhere = "submitted"
+	UserComments  UserWhere = "comments"
+)
+
+// UserListing is a query for the links and comments of a user. It implements URLer and Lister and can be
+// used with Config.Stream to stream the activity of a user.
+type UserListing struct {
+	ListingOptions
+	User     string      `url:"-"`
+	Where    UserWhere   `url:"-"` // Defaults to UserOverview.
+	Sort     LinkSort    `url:"sort,omitempty"`
+	Duration TopDuration `url:"t,omitempty"`
+}
+
+// URL returns the URL to use when fetching the activity of a user.
+func (u *UserListing) URL() (string, error) {
+	v, err := query.Values(u)
+	if err != nil {
+		return "", err
+	}
+	where := u.Where
+	if where == "" {
+		where = UserOverview
+	}
+	return fmt.Sprintf("%s/user/%s/%s.json?%s", RedditAPIURL, u.User, where, v.Encode()), nil
+}
+
+// List returns the ListingOptions for UserListing
+func (u *UserListing) List() *ListingOptions { return &u.ListingOptions }
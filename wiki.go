@@ -0,0 +1,185 @@
+package reddit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/go-querystring/query"
+)
+
+// WikiPermLevel controls who may edit a wiki page.
+type WikiPermLevel int
+
+// Supported permission levels for a wiki page.
+const (
+	WikiPermSubreddit WikiPermLevel = 0 // Use the wiki settings of the subreddit.
+	WikiPermApproved  WikiPermLevel = 1 // Only approved wiki contributors may edit.
+	WikiPermMods      WikiPermLevel = 2 // Only moderators may edit.
+)
+
+func wikiURL(subreddit, path string) string {
+	return fmt.Sprintf("%s/r/%s/wiki/%s", RedditAPIURL, subreddit, path)
+}
+
+// WikiPages returns the names of all wiki pages of subreddit.
+func (c *Config) WikiPages(client *http.Client, subreddit string) ([]string, error) {
+	var r struct {
+		Data []string `json:"data"`
+	}
+	if err := c.Get(client, wikiURL(subreddit, "pages.json"), &r); err != nil {
+		return nil, err
+	}
+	return r.Data, nil
+}
+
+// WikiPage fetches a wiki page of subreddit. If revision is non-empty, that revision of the page is returned
+// instead of the current one.
+func (c *Config) WikiPage(client *http.Client, subreddit, page, revision string) (*WikiPage, error) {
+	u := wikiURL(subreddit, page+".json")
+	if revision != "" {
+		u += "?v=" + url.QueryEscape(revision)
+	}
+	var t Thing
+	if err := c.Get(client, u, &t); err != nil {
+		return nil, err
+	}
+	p, ok := t.Data.(*WikiPage)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %s response from %s", t.Kind, u)
+	}
+	return p, nil
+}
+
+// WikiEdit is an edit to a wiki page.
+type WikiEdit struct {
+	Page     string `url:"page"`
+	Content  string `url:"content"`
+	Reason   string `url:"reason,omitempty"`
+	Previous string `url:"previous,omitempty"` // ID of the revision the edit is based on.
+}
+
+// WikiConflictError is returned by Config.EditWikiPage when the page was modified after the revision the
+// edit was based on.
+type WikiConflictError struct {
+	NewContent  string `json:"newcontent"`  // Current content of the page.
+	NewRevision string `json:"newrevision"` // ID of the current revision of the page.
+	DiffContent string `json:"diffcontent"` // HTML diff between the edit and the current content.
+}
+
+func (e *WikiConflictError) Error() string {
+	return fmt.Sprintf("wiki edit conflicts with revision %s", e.NewRevision)
+}
+
+// EditWikiPage edits a wiki page of subreddit, creating it if it does not exist. If e.Previous is set and the
+// page has been edited since that revision, a *WikiConflictError is returned and the page is left unchanged.
+func (c *Config) EditWikiPage(client *http.Client, subreddit string, e WikiEdit) error {
+	form, err := query.Values(e)
+	if err != nil {
+		return err
+	}
+	err = c.Post(client, subredditAPIURL(subreddit, "wiki/edit"), form, nil)
+	if se, ok := err.(*StatusError); ok && se.StatusCode == http.StatusConflict {
+		var conflict WikiConflictError
+		if json.Unmarshal(se.Body, &conflict) == nil {
+			return &conflict
+		}
+	}
+	return err
+}
+
+// RevertWikiPage reverts a wiki page of subreddit to the revision with the provided ID.
+func (c *Config) RevertWikiPage(client *http.Client, subreddit, page, revision string) error {
+	form := url.Values{"page": {page}, "revision": {revision}}
+	return c.Post(client, subredditAPIURL(subreddit, "wiki/revert"), form, nil)
+}
+
+// WikiRevisions is a query for the revisions of a subreddit's wiki. It implements URLer. If Page is empty,
+// revisions of all pages are listed. Revisions are not Things, so page through them with
+// Config.WikiRevisions rather than a Stream.
+type WikiRevisions struct {
+	ListingOptions
+	SubReddit string `url:"-"`
+	Page      string `url:"-"`
+}
+
+// URL returns the URL to use when fetching wiki revisions.
+func (w *WikiRevisions) URL() (string, error) {
+	v, err := query.Values(w)
+	if err != nil {
+		return "", err
+	}
+	path := "revisions.json"
+	if w.Page != "" {
+		path = "revisions/" + w.Page + ".json"
+	}
+	return fmt.Sprintf("%s?%s", wikiURL(w.SubReddit, path), v.Encode()), nil
+}
+
+// WikiRevisions fetches a page of wiki revisions, newest first. The After and Count values of q are updated
+// so that calling WikiRevisions repeatedly with the same query pages through all revisions. An empty slice
+// is returned once all revisions have been read.
+func (c *Config) WikiRevisions(client *http.Client, q *WikiRevisions) ([]WikiRevision, error) {
+	if q.Count > 0 && q.After == "" {
+		return nil, nil
+	}
+	u, err := q.URL()
+	if err != nil {
+		return nil, err
+	}
+	var r struct {
+		Data struct {
+			After    string         `json:"after"`
+			Children []WikiRevision `json:"children"`
+		} `json:"data"`
+	}
+	if err := c.Get(client, u, &r); err != nil {
+		return nil, err
+	}
+	q.After, q.Count = r.Data.After, q.Count+len(r.Data.Children)
+	return r.Data.Children, nil
+}
+
+// WikiPageSettings fetches the permission settings of a wiki page of subreddit.
+func (c *Config) WikiPageSettings(client *http.Client, subreddit, page string) (*WikiPageSettings, error) {
+	u := wikiURL(subreddit, "settings/"+page+".json")
+	var t Thing
+	if err := c.Get(client, u, &t); err != nil {
+		return nil, err
+	}
+	s, ok := t.Data.(*WikiPageSettings)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %s response from %s", t.Kind, u)
+	}
+	return s, nil
+}
+
+// SetWikiPageSettings sets who may edit a wiki page of subreddit and whether it is shown in the list of pages.
+func (c *Config) SetWikiPageSettings(client *http.Client, subreddit, page string, perm WikiPermLevel, listed bool) error {
+	form := url.Values{"page": {page}, "permlevel": {strconv.Itoa(int(perm))}, "listed": {strconv.FormatBool(listed)}}
+	return c.Post(client, wikiURL(subreddit, "settings/"+page), form, nil)
+}
+
+// AddWikiEditor allows user to edit a wiki page of subreddit regardless of the page's permission level.
+func (c *Config) AddWikiEditor(client *http.Client, subreddit, page, user string) error {
+	form := url.Values{"page": {page}, "username": {user}}
+	return c.Post(client, subredditAPIURL(subreddit, "wiki/alloweditor/add"), form, nil)
+}
+
+// RemoveWikiEditor reverses AddWikiEditor.
+func (c *Config) RemoveWikiEditor(client *http.Client, subreddit, page, user string) error {
+	form := url.Values{"page": {page}, "username": {user}}
+	return c.Post(client, subredditAPIURL(subreddit, "wiki/alloweditor/del"), form, nil)
+}
+
+// WikiRevision represents a single revision in the history of a subreddit wiki.
+type WikiRevision struct {
+	Author         *Thing    `json:"author"`
+	ID             string    `json:"id"`
+	Page           string    `json:"page"`
+	Reason         string    `json:"reason"`
+	RevisionHidden bool      `json:"revision_hidden"`
+	Timestamp      Timestamp `json:"timestamp"`
+}
@@ -0,0 +1,84 @@
+package reddit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_WikiPage(t *testing.T) {
+	m := mock(
+		get("https://oauth.reddit.com/r/golang/wiki/config/sidebar.json?v=rev-1", `{"kind": "wikipage", "data": {
+			"content_md": "# Rules", "may_revise": true, "reason": "sync", "revision_id": "rev-1",
+			"revision_date": 1500000000, "revision_by": {"kind": "t2", "data": {"name": "somemod"}}
+		}}`),
+		get("https://oauth.reddit.com/r/golang/wiki/pages.json", `{"kind": "wikipagelisting", "data": ["index", "config/sidebar"]}`),
+	)
+	defer m.reset()
+
+	require := require.New(t)
+	c := authedConfig(m)
+
+	page, err := c.WikiPage(nil, "golang", "config/sidebar", "rev-1")
+	require.NoError(err)
+	require.Equal("# Rules", page.ContentMD)
+	require.Equal("rev-1", page.RevisionID)
+	require.Equal("somemod", page.RevisionBy.Data.(*Account).Name)
+
+	pages, err := c.WikiPages(nil, "golang")
+	require.NoError(err)
+	require.Equal([]string{"index", "config/sidebar"}, pages)
+}
+
+func TestConfig_EditWikiPage(t *testing.T) {
+	m := mock(
+		post("https://oauth.reddit.com/r/golang/api/wiki/edit", "content=%23+Rules&page=rules&previous=rev-1&reason=sync", "{}"),
+		response{
+			statusCode: 409,
+			headers:    requestHeaders,
+			requestURL: "https://oauth.reddit.com/r/golang/api/wiki/edit",
+			body:       "content=%23+Rules&page=rules&previous=rev-1&reason=sync",
+			response: `{"message": "Conflict", "reason": "EDIT_CONFLICT", "newcontent": "# Other rules",
+				"newrevision": "rev-2", "diffcontent": "<ins>Other</ins>"}`,
+		},
+	)
+	defer m.reset()
+
+	require := require.New(t)
+	c := authedConfig(m)
+	edit := WikiEdit{Page: "rules", Content: "# Rules", Reason: "sync", Previous: "rev-1"}
+
+	require.NoError(c.EditWikiPage(nil, "golang", edit))
+	err := c.EditWikiPage(nil, "golang", edit)
+	require.Equal(&WikiConflictError{NewContent: "# Other rules", NewRevision: "rev-2", DiffContent: "<ins>Other</ins>"}, err)
+}
+
+func TestConfig_WikiRevisions(t *testing.T) {
+	m := mock(
+		get("https://oauth.reddit.com/r/golang/wiki/revisions/rules.json?limit=1", `{"kind": "Listing", "data": {
+			"after": "WikiRevision_rev-2", "children": [
+				{"id": "rev-2", "page": "rules", "reason": "typo", "timestamp": 1500000100,
+				 "author": {"kind": "t2", "data": {"name": "somemod"}}}
+			]}}`),
+		get("https://oauth.reddit.com/r/golang/wiki/revisions/rules.json?after=WikiRevision_rev-2&count=1&limit=1",
+			`{"kind": "Listing", "data": {"after": null, "children": [{"id": "rev-1", "page": "rules"}]}}`),
+	)
+	defer m.reset()
+
+	require := require.New(t)
+	c := authedConfig(m)
+	q := &WikiRevisions{SubReddit: "golang", Page: "rules", ListingOptions: ListingOptions{Limit: 1}}
+
+	var ids []string
+	for {
+		revs, err := c.WikiRevisions(nil, q)
+		require.NoError(err)
+		if len(revs) == 0 {
+			break
+		}
+		for _, r := range revs {
+			ids = append(ids, r.ID)
+		}
+	}
+	require.Equal([]string{"rev-2", "rev-1"}, ids)
+}
@@ -0,0 +1,113 @@
+package reddit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Post performs an authenticated POST request to the provided URL using the provided http.Client instance.
+// The form values are sent URL encoded in the request body. If val is non-nil the response is unmarshalled into it.
+//
+// Errors reported by reddit in the body of the response (for calls made with api_type=json) are returned
+// as APIErrors.
+func (c *Config) Post(client *http.Client, url string, form url.Values, val interface{}) error {
+	return c.do(client, http.MethodPost, url, form, val)
+}
+
+// APIError is a single error reported by reddit in the body of a successful HTTP response.
+type APIError struct {
+	Code    string // Error code, for example SUBREDDIT_NOEXIST
+	Message string // Human readable error message
+	Field   string // Name of the form field the error relates to, if any
+}
+
+func (e APIError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%s: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("%s: %s (%s)", e.Code, e.Message, e.Field)
+}
+
+// APIErrors holds all errors reported by reddit for a single request.
+type APIErrors []APIError
+
+func (e APIErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// apiErrors extracts errors from a response of the form {"json": {"errors": [[code, message, field]]}}.
+// Responses that are not of this form have no errors.
+func apiErrors(data []byte) error {
+	var r struct {
+		JSON struct {
+			Errors [][]string `json:"errors"`
+		} `json:"json"`
+	}
+	if err := json.Unmarshal(data, &r); err != nil || len(r.JSON.Errors) == 0 {
+		return nil
+	}
+	errs := make(APIErrors, len(r.JSON.Errors))
+	for i, e := range r.JSON.Errors {
+		for j, s := range e {
+			switch j {
+			case 0:
+				errs[i].Code = s
+			case 1:
+				errs[i].Message = s
+			case 2:
+				errs[i].Field = s
+			}
+		}
+	}
+	return errs
+}
+
+// do performs an authenticated request. If form is non-nil it is sent URL encoded in the request body.
+func (c *Config) do(client *http.Client, method, url string, form url.Values, val interface{}) error {
+	return c.request(client, method, url, form, val, RequestInfo{})
+}
+
+// request is like do, using base as the RequestInfo passed to middleware.
+func (c *Config) request(client *http.Client, method, url string, form url.Values, val interface{}, base RequestInfo) error {
+	url = c.apiURL(url)
+	var body io.Reader
+	if form != nil {
+		body = bytes.NewBufferString(form.Encode())
+	}
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request for %s: %v", url, err)
+	}
+	if form != nil {
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	}
+	req.Header.Add("User-Agent", c.userAgent())
+	req.Header.Add("Authorization", fmt.Sprintf("%s %s", c.AuthToken.Type, c.AuthToken.Token))
+
+	base.Request = req
+	_, err = c.httpRequest(client, base, func(info *RequestInfo) error {
+		if err := statusOK(info); err != nil {
+			return err
+		}
+		if err := apiErrors(info.Body); err != nil {
+			return err
+		}
+		if val == nil {
+			return nil
+		}
+		if err := c.settings.decode.Unmarshal(info.Body, val); err != nil {
+			return fmt.Errorf("failed to parse response from %s: %v", url, err)
+		}
+		return nil
+	})
+	return err
+}